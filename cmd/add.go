@@ -0,0 +1,513 @@
+package cmd
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/algarys/algarys_cli/cmd/ui"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// Templates dos componentes gerados pelo `algarys add`
+//
+//go:embed templates/components
+var componentTemplatesFS embed.FS
+
+// ComponentContext são os dados disponíveis para os templates de componentes
+type ComponentContext struct {
+	Module    string // pacote Python do projeto
+	Name      string // nome em snake_case (ex: send_email)
+	ClassName string // nome em PascalCase (ex: SendEmail)
+}
+
+// componentKind descreve um tipo de componente gerado pelo `algarys add`
+type componentKind struct {
+	name     string
+	aliases  []string
+	short    string
+	pkg      string // pacote relativo ao módulo (ex: domain/entities)
+	template string
+	symbol   func(ctx ComponentContext) string // nome exportado no __init__.py
+	register string                            // lista no worker do Temporal (activities/workflows)
+}
+
+var componentKinds = []componentKind{
+	{
+		name: "entity", short: "Cria uma entidade em domain/entities",
+		pkg: "domain/entities", template: "entity.py.tmpl",
+		symbol: func(c ComponentContext) string { return c.ClassName },
+	},
+	{
+		name: "usecase", aliases: []string{"use-case", "use_case"}, short: "Cria um caso de uso em application/use_cases",
+		pkg: "application/use_cases", template: "usecase.py.tmpl",
+		symbol: func(c ComponentContext) string { return c.ClassName + "UseCase" },
+	},
+	{
+		name: "agent", short: "Cria um agente de IA em ai/agents",
+		pkg: "ai/agents", template: "agent.py.tmpl",
+		symbol: func(c ComponentContext) string { return c.ClassName + "Agent" },
+	},
+	{
+		name: "tool", short: "Cria uma ferramenta de agente em ai/tools",
+		pkg: "ai/tools", template: "tool.py.tmpl",
+		symbol: func(c ComponentContext) string { return c.ClassName + "Tool" },
+	},
+	{
+		name: "activity", short: "Cria uma activity do Temporal e registra no worker",
+		pkg: "temporal/activities", template: "activity.py.tmpl",
+		symbol:   func(c ComponentContext) string { return c.Name },
+		register: "activities",
+	},
+	{
+		name: "workflow", short: "Cria um workflow do Temporal e registra no worker",
+		pkg: "temporal/workflows", template: "workflow.py.tmpl",
+		symbol:   func(c ComponentContext) string { return c.ClassName + "Workflow" },
+		register: "workflows",
+	},
+}
+
+var addForce bool
+
+var addCmd = &cobra.Command{
+	Use:   "add <tipo> <nome>",
+	Short: "Adiciona componentes a um projeto existente",
+	Long: `Gera componentes dentro de um projeto criado com 'algarys init'.
+
+Exemplos:
+  algarys add entity Invoice
+  algarys add usecase create_invoice
+  algarys add agent triage
+  algarys add tool search_docs
+  algarys add activity send_email
+  algarys add workflow billing
+  algarys add api
+
+O arquivo é criado a partir do template, exportado no __init__.py do
+pacote e, para activities e workflows, registrado no worker do Temporal.
+Arquivos existentes não são sobrescritos sem --force.
+
+Módulos (ex: api) habilitam no projeto o que o 'algarys init' geraria
+para eles, com merge de três vias nos arquivos já existentes.`,
+}
+
+func init() {
+	addCmd.PersistentFlags().BoolVarP(&addForce, "force", "f", false, "Sobrescrever arquivos existentes")
+
+	for _, kind := range componentKinds {
+		kind := kind
+		addCmd.AddCommand(&cobra.Command{
+			Use:     kind.name + " <nome>",
+			Aliases: kind.aliases,
+			Short:   kind.short,
+			Args:    cobra.ExactArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				runAddComponent(kind, args[0])
+			},
+		})
+	}
+
+	rootCmd.AddCommand(addCmd)
+}
+
+func runAddComponent(kind componentKind, rawName string) {
+	fmt.Println()
+
+	root, module, err := findProjectModule()
+	if err != nil {
+		fmt.Println(ui.RenderError(err.Error()))
+		fmt.Println()
+		os.Exit(1)
+	}
+
+	ctx, err := newComponentContext(module, rawName)
+	if err != nil {
+		fmt.Println(ui.RenderError(err.Error()))
+		fmt.Println()
+		os.Exit(1)
+	}
+
+	created, err := addComponent(root, kind, ctx, addForce)
+	if err != nil {
+		fmt.Println(ui.RenderError(err.Error()))
+		fmt.Println()
+		os.Exit(1)
+	}
+
+	for _, msg := range created {
+		fmt.Println(ui.RenderSuccess(msg))
+	}
+	fmt.Println()
+	fmt.Println(lipgloss.NewStyle().Foreground(ui.Muted).Italic(true).PaddingLeft(2).Render(
+		fmt.Sprintf("%s %s adicionado em %s", ui.IconMagic, kind.symbol(ctx), module),
+	))
+	fmt.Println()
+}
+
+// addComponent gera o componente e devolve as mensagens de cada alteração
+// feita. Todas as alterações são calculadas antes de gravar qualquer arquivo.
+func addComponent(root string, kind componentKind, ctx ComponentContext, force bool) ([]string, error) {
+	pkgDir := filepath.Join(root, ctx.Module, filepath.FromSlash(kind.pkg))
+	if _, err := os.Stat(pkgDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("pacote %s não existe neste projeto (módulo não selecionado no init?)", filepath.ToSlash(filepath.Join(ctx.Module, kind.pkg)))
+	}
+
+	target := filepath.Join(pkgDir, ctx.Name+".py")
+	if _, err := os.Stat(target); err == nil && !force {
+		return nil, fmt.Errorf("%s já existe (use --force para sobrescrever)", relPath(root, target))
+	}
+
+	tmpl, err := componentTemplatesFS.ReadFile("templates/components/" + kind.template)
+	if err != nil {
+		return nil, err
+	}
+	content, err := renderContent(kind.template, tmpl, ctx)
+	if err != nil {
+		return nil, err
+	}
+	edits := []fileEdit{{path: target, content: content}}
+	messages := []string{"Criado: " + relPath(root, target)}
+
+	// Exportar no __init__.py do pacote (re-export explícito, aceito pelo
+	// ruff e pelo mypy --strict)
+	modulePath := ctx.Module + "." + strings.ReplaceAll(kind.pkg, "/", ".") + "." + ctx.Name
+	symbol := kind.symbol(ctx)
+	initFile := filepath.Join(pkgDir, "__init__.py")
+	initContent, err := readOptionalFile(initFile)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler %s: %v", relPath(root, initFile), err)
+	}
+	if updated, changed := withImport(initContent, modulePath, symbol+" as "+symbol, symbol); changed {
+		edits = append(edits, fileEdit{path: initFile, content: []byte(updated)})
+		messages = append(messages, "Atualizado: "+relPath(root, initFile))
+	}
+
+	// Registrar no worker do Temporal
+	if kind.register != "" {
+		workerFile := filepath.Join(root, ctx.Module, "temporal", "worker", "main.py")
+		worker, err := os.ReadFile(workerFile)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler o worker: %v", err)
+		}
+		updated, changed, err := registerInWorker(string(worker), kind.register, modulePath, symbol)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao registrar no worker: %v (registre manualmente em %s)", err, relPath(root, workerFile))
+		}
+		if changed {
+			edits = append(edits, fileEdit{path: workerFile, content: []byte(updated)})
+			messages = append(messages, fmt.Sprintf("Registrado em %s (%s)", relPath(root, workerFile), kind.register))
+		}
+	}
+
+	if err := applyFileEdits(edits); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// fileEdit é um arquivo a gravar com o conteúdo completo
+type fileEdit struct {
+	path    string
+	content []byte
+}
+
+// applyFileEdits grava as edições em ordem; se uma falhar, as anteriores
+// são desfeitas (arquivos restaurados ou removidos)
+func applyFileEdits(edits []fileEdit) error {
+	type backup struct {
+		path    string
+		content []byte
+		existed bool
+	}
+	var done []backup
+	rollback := func() {
+		for i := len(done) - 1; i >= 0; i-- {
+			if done[i].existed {
+				os.WriteFile(done[i].path, done[i].content, 0644)
+			} else {
+				os.Remove(done[i].path)
+			}
+		}
+	}
+
+	for _, e := range edits {
+		original, err := os.ReadFile(e.path)
+		if err != nil && !os.IsNotExist(err) {
+			rollback()
+			return err
+		}
+		if err := os.WriteFile(e.path, e.content, 0644); err != nil {
+			rollback()
+			return fmt.Errorf("erro ao gravar %s: %v", e.path, err)
+		}
+		done = append(done, backup{path: e.path, content: original, existed: err == nil})
+	}
+	return nil
+}
+
+func readOptionalFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func newComponentContext(module, rawName string) (ComponentContext, error) {
+	name := toSnakeCase(rawName)
+	if !pythonIdentifier.MatchString(name) {
+		return ComponentContext{}, fmt.Errorf("nome inválido: %s", rawName)
+	}
+	className := toPascalCase(name)
+	if pythonKeywords[name] || pythonKeywords[className] {
+		return ComponentContext{}, fmt.Errorf("nome inválido: %s é uma palavra reservada do Python", rawName)
+	}
+	return ComponentContext{
+		Module:    module,
+		Name:      name,
+		ClassName: className,
+	}, nil
+}
+
+var pythonIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// toSnakeCase converte Invoice, SendEmail, send-email ou "send email" em snake_case
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(strings.TrimSpace(s))
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == ' ' || r == '.':
+			b.WriteRune('_')
+		case unicode.IsUpper(r):
+			if i > 0 && runes[i-1] != '_' && runes[i-1] != '-' && runes[i-1] != ' ' &&
+				(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func toPascalCase(snake string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(snake, "_") {
+		if part == "" {
+			continue
+		}
+		runes := []rune(part)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	return b.String()
+}
+
+// withImport importa names de module no conteúdo, na posição ordenada;
+// aliases são formas equivalentes já aceitas
+func withImport(content, module, names string, aliases ...string) (string, bool) {
+	for _, n := range append([]string{names}, aliases...) {
+		if hasImport(content, module, n) {
+			return content, false
+		}
+	}
+	return insertImport(content, formatImport(module, names)), true
+}
+
+// registerInWorker importa symbol no worker e o adiciona na lista
+// `workflows=[...]` ou `activities=[...]` do Worker
+func registerInWorker(content, list, module, symbol string) (string, bool, error) {
+	listRe := regexp.MustCompile(`(?s)(\b` + list + `=\[)(.*?)(\])`)
+	match := listRe.FindStringSubmatchIndex(content)
+	if match == nil {
+		return "", false, fmt.Errorf("lista %s=[...] não encontrada", list)
+	}
+
+	items := content[match[4]:match[5]]
+	for _, item := range strings.Split(items, ",") {
+		if strings.TrimSpace(item) == symbol {
+			return content, false, nil
+		}
+	}
+
+	newItems := symbol
+	if trimmed := strings.TrimRight(strings.TrimSpace(items), ","); trimmed != "" {
+		newItems = trimmed + ", " + symbol
+	}
+	content = content[:match[4]] + newItems + content[match[5]:]
+
+	content, _ = withImport(content, module, symbol)
+	return content, true, nil
+}
+
+// Limite de linha do ruff no pyproject.toml gerado
+const pythonLineLength = 88
+
+// formatImport monta `from module import names` como o isort do ruff
+// formataria, quebrando em bloco entre parênteses acima do limite de linha
+func formatImport(module, names string) string {
+	line := fmt.Sprintf("from %s import %s", module, names)
+	if len(line) <= pythonLineLength {
+		return line
+	}
+	return fmt.Sprintf("from %s import (\n    %s,\n)", module, names)
+}
+
+// pythonImport é um import de nível de módulo, possivelmente em várias
+// linhas (`from x import (`...`)`), ocupando as linhas [start, end)
+type pythonImport struct {
+	start, end int
+	module     string
+	names      []string
+}
+
+func parseImports(lines []string) []pythonImport {
+	var imports []pythonImport
+	for i := 0; i < len(lines); i++ {
+		l := lines[i]
+		if !strings.HasPrefix(l, "from ") && !strings.HasPrefix(l, "import ") {
+			continue
+		}
+		fields := strings.Fields(l)
+		if len(fields) < 2 {
+			continue
+		}
+		imp := pythonImport{start: i, module: fields[1]}
+		body := l
+		if idx := strings.Index(l, " import "); idx >= 0 {
+			body = l[idx+len(" import "):]
+		}
+		if strings.HasSuffix(strings.TrimSpace(l), "(") {
+			body = ""
+			for i+1 < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), ")") {
+				i++
+				body += lines[i] + ","
+			}
+		}
+		for _, n := range strings.Split(strings.Trim(body, "()"), ",") {
+			if n = strings.Join(strings.Fields(strings.Trim(n, "()")), " "); n != "" {
+				imp.names = append(imp.names, n)
+			}
+		}
+		imp.end = i + 1
+		imports = append(imports, imp)
+	}
+	return imports
+}
+
+// hasImport diz se o arquivo já faz `from module import name`
+func hasImport(content, module, name string) bool {
+	for _, imp := range parseImports(strings.Split(content, "\n")) {
+		if imp.module != module {
+			continue
+		}
+		for _, n := range imp.names {
+			if n == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// insertImport insere stmt entre os imports do mesmo pacote raiz, na ordem
+// do isort (por módulo, sem diferenciar maiúsculas). Sem imports do pacote,
+// abre uma seção nova depois do último import, ou vai para o fim do arquivo.
+func insertImport(content, stmt string) string {
+	module := strings.Fields(stmt)[1]
+	root := strings.SplitN(module, ".", 2)[0]
+
+	lines := strings.Split(content, "\n")
+	imports := parseImports(lines)
+
+	insertAt := -1
+	for _, imp := range imports {
+		if strings.SplitN(imp.module, ".", 2)[0] != root {
+			continue
+		}
+		if strings.ToLower(imp.module) > strings.ToLower(module) {
+			insertAt = imp.start
+			break
+		}
+		insertAt = imp.end
+	}
+
+	newLines := strings.Split(stmt, "\n")
+	if insertAt < 0 {
+		if len(imports) == 0 {
+			if content != "" && !strings.HasSuffix(content, "\n") {
+				content += "\n"
+			}
+			return content + stmt + "\n"
+		}
+		insertAt = imports[len(imports)-1].end
+		newLines = append([]string{""}, newLines...)
+	}
+
+	lines = append(lines[:insertAt], append(newLines, lines[insertAt:]...)...)
+	return strings.Join(lines, "\n")
+}
+
+// findProjectModule localiza o projeto pelo .algarys.toml e, em projetos
+// sem manifesto, procura o pyproject.toml e descobre o pacote Python
+func findProjectModule() (root, module string, err error) {
+	root, manifest, err := loadCurrentProject()
+	if err == nil && manifest.Module != "" {
+		return root, manifest.Module, nil
+	}
+	if err != nil && err != errNotAlgarysProject {
+		return "", "", err
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", "", err
+	}
+
+	for {
+		pyproject := filepath.Join(dir, "pyproject.toml")
+		if data, err := os.ReadFile(pyproject); err == nil {
+			module := moduleFromPyproject(string(data))
+			if module == "" {
+				return "", "", fmt.Errorf("não foi possível descobrir o pacote Python em %s", pyproject)
+			}
+			return dir, module, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", fmt.Errorf("nenhum projeto encontrado (pyproject.toml) a partir do diretório atual")
+		}
+		dir = parent
+	}
+}
+
+var (
+	hatchPackagesRe = regexp.MustCompile(`(?m)^packages\s*=\s*\["([^"]+)"`)
+	projectNameRe   = regexp.MustCompile(`(?m)^name\s*=\s*"([^"]+)"`)
+)
+
+// moduleFromPyproject lê o pacote do hatch ou deriva do nome do projeto
+func moduleFromPyproject(content string) string {
+	if m := hatchPackagesRe.FindStringSubmatch(content); m != nil {
+		return m[1]
+	}
+	if m := projectNameRe.FindStringSubmatch(content); m != nil {
+		return strings.ReplaceAll(m[1], "-", "_")
+	}
+	return ""
+}
+
+func relPath(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/algarys/algarys_cli/cmd/ui"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// moduleGenerator habilita num projeto existente um módulo opcional do
+// template (o mesmo que seria escolhido no `algarys init`)
+type moduleGenerator struct {
+	module string
+	short  string
+	next   []string // próximos passos (templates com o TemplateContext)
+}
+
+var moduleGenerators = []moduleGenerator{
+	{
+		module: "api",
+		short:  "Adiciona a API HTTP (FastAPI) em interfaces/api",
+		next:   []string{"uv sync --extra api", "uv run {{.Name}}-api"},
+	},
+	{
+		module: "cli",
+		short:  "Adiciona a CLI (Typer) em interfaces/cli",
+		next:   []string{"uv sync", "uv run {{.Name}} --help"},
+	},
+	{
+		module: "docker",
+		short:  "Adiciona Dockerfile e docker-compose (Temporal, Postgres, worker/API)",
+		next:   []string{"uv lock", "docker compose up --build"},
+	},
+}
+
+func init() {
+	for _, gen := range moduleGenerators {
+		gen := gen
+		addCmd.AddCommand(&cobra.Command{
+			Use:   gen.module,
+			Short: gen.short,
+			Args:  cobra.NoArgs,
+			Run: func(cmd *cobra.Command, args []string) {
+				runAddModule(gen)
+			},
+		})
+	}
+}
+
+func runAddModule(gen moduleGenerator) {
+	fmt.Println()
+
+	root, manifest, err := loadCurrentProject()
+	if err != nil {
+		fmt.Println(ui.RenderError(err.Error()))
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.Muted).PaddingLeft(2).Render(
+			fmt.Sprintf("'algarys add %s' precisa do %s gerado pelo 'algarys init'", gen.module, projectManifestFile),
+		))
+		fmt.Println()
+		os.Exit(1)
+	}
+
+	if modulesSet(manifest.Project.Modules)[gen.module] {
+		fmt.Println(ui.RenderInfo(fmt.Sprintf("Módulo %s já está habilitado neste projeto", gen.module)))
+		fmt.Println()
+		return
+	}
+
+	spinner := ui.NewSpinner(ui.IconPackage + "  Renderizando template")
+	spinner.Start()
+
+	updated, changes, err := planModuleAddition(root, *manifest, gen.module)
+	if err != nil {
+		spinner.Error("Erro ao renderizar o template")
+		fmt.Println(ui.RenderError(err.Error()))
+		fmt.Println()
+		os.Exit(1)
+	}
+	spinner.Stop()
+
+	if len(changes) == 0 {
+		fmt.Println(ui.RenderWarning(fmt.Sprintf("O template deste projeto não gera nada para o módulo %s", gen.module)))
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.Muted).PaddingLeft(2).Render(
+			"Rode 'algarys upgrade-project' para atualizar o template e tente de novo",
+		))
+		fmt.Println()
+		os.Exit(1)
+	}
+
+	if err := applyUpgrade(root, changes); err != nil {
+		fmt.Println(ui.RenderError(fmt.Sprintf("Erro ao aplicar: %v", err)))
+		fmt.Println()
+		os.Exit(1)
+	}
+
+	manifest.Project = updated
+	if err := writeProjectManifest(root, *manifest); err != nil {
+		fmt.Println(ui.RenderError(fmt.Sprintf("Erro ao atualizar %s: %v", projectManifestFile, err)))
+		os.Exit(1)
+	}
+
+	fmt.Println(lipgloss.NewStyle().Foreground(ui.Muted).Italic(true).PaddingLeft(2).Render(
+		fmt.Sprintf("%s Módulo %s adicionado. Próximos passos:", ui.IconMagic, gen.module),
+	))
+	fmt.Println()
+	ctx := newTemplateContext(updated, manifest.Module)
+	for _, step := range gen.next {
+		line, err := renderContent("next", []byte(step), ctx)
+		if err != nil {
+			continue
+		}
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.Primary).PaddingLeft(4).Render(string(line)))
+	}
+	fmt.Println()
+}
+
+// planModuleAddition renderiza o template do projeto (na mesma revisão) sem
+// e com o módulo; a diferença entre os dois é aplicada com o mesmo merge de
+// três vias do upgrade-project, preservando as alterações locais
+func planModuleAddition(root string, m ProjectManifest, module string) (ProjectConfig, []fileChange, error) {
+	updated := m.Project
+	updated.Modules = withRequiredModules(append(append([]string(nil), m.Project.Modules...), module))
+	updated.Preset = customPreset
+	for _, p := range projectPresets {
+		if sameModules(p.modules, updated.Modules) {
+			updated.Preset = p.key
+		}
+	}
+	// O módulo novo usa a opção padrão das suas escolhas (ex: llm_provider)
+	if err := resolveModuleChoices(&updated); err != nil {
+		return updated, nil, err
+	}
+
+	tpl, cleanup, err := templateAtRevision(m.Template)
+	if err != nil {
+		return updated, nil, err
+	}
+	defer cleanup()
+
+	before, err := renderTemplate(tpl.FS, newTemplateContext(m.Project, m.Module))
+	if err != nil {
+		return updated, nil, err
+	}
+	after, err := renderTemplate(tpl.FS, newTemplateContext(updated, m.Module))
+	if err != nil {
+		return updated, nil, err
+	}
+
+	changes, err := planUpgrade(root, before, after)
+	return updated, changes, err
+}
+
+func sameModules(a, b []string) bool {
+	a, b = normalizeModules(a), normalizeModules(b)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
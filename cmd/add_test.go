@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// renderTestProject gera o template padrão com todos os módulos em um
+// diretório temporário e devolve a raiz do projeto
+func renderTestProject(t *testing.T) string {
+	t.Helper()
+
+	config := ProjectConfig{Name: "demo", PythonVersion: "3.12", Modules: moduleKeys()}
+	files, err := renderTemplate(defaultTemplate(), newTemplateContext(config, "demo"))
+	if err != nil {
+		t.Fatalf("renderTemplate: %v", err)
+	}
+	root := t.TempDir()
+	if err := writeRenderedFiles(root, files); err != nil {
+		t.Fatalf("writeRenderedFiles: %v", err)
+	}
+	return root
+}
+
+// assertPythonParses valida a sintaxe com o python3 do sistema, se houver
+func assertPythonParses(t *testing.T, path string) {
+	t.Helper()
+
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Logf("python3 não encontrado, sintaxe de %s não verificada", path)
+		return
+	}
+	out, err := exec.Command(python, "-c", "import ast, sys; ast.parse(open(sys.argv[1]).read())", path).CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s não é Python válido: %v\n%s", path, err, out)
+	}
+}
+
+func TestAddRegistersComponentsInWorker(t *testing.T) {
+	root := renderTestProject(t)
+
+	for _, add := range []struct{ kind, name string }{
+		{"activity", "send_email"},
+		{"workflow", "billing"},
+	} {
+		var kind componentKind
+		for _, k := range componentKinds {
+			if k.name == add.kind {
+				kind = k
+			}
+		}
+		ctx, err := newComponentContext("demo", add.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := addComponent(root, kind, ctx, false); err != nil {
+			t.Fatalf("add %s %s: %v", add.kind, add.name, err)
+		}
+	}
+
+	workerFile := filepath.Join(root, "demo", "temporal", "worker", "main.py")
+	assertPythonParses(t, workerFile)
+
+	data, err := os.ReadFile(workerFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worker := string(data)
+
+	var modules []string
+	for _, imp := range parseImports(strings.Split(worker, "\n")) {
+		if strings.HasPrefix(imp.module, "demo.") {
+			modules = append(modules, imp.module)
+		}
+	}
+	want := []string{
+		"demo.settings",
+		"demo.temporal.activities.ai_activities",
+		"demo.temporal.activities.send_email",
+		"demo.temporal.client",
+		"demo.temporal.workflows.ai_workflow",
+		"demo.temporal.workflows.billing",
+	}
+	if strings.Join(modules, " ") != strings.Join(want, " ") {
+		t.Errorf("imports do worker fora de ordem:\n got: %v\nwant: %v", modules, want)
+	}
+
+	for _, s := range []string{
+		"workflows=[AIProcessingWorkflow, BillingWorkflow]",
+		"activities=[process_data, call_ai_agent, send_email]",
+	} {
+		if !strings.Contains(worker, s) {
+			t.Errorf("worker sem %q:\n%s", s, worker)
+		}
+	}
+}
+
+func TestInsertImport(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		stmt    string
+		want    string
+	}{
+		{
+			name:    "arquivo vazio",
+			content: "",
+			stmt:    "from app.a import A as A",
+			want:    "from app.a import A as A\n",
+		},
+		{
+			name:    "ordenado entre imports do pacote",
+			content: "from app.a import A as A\nfrom app.c import C as C\n",
+			stmt:    "from app.b import B as B",
+			want:    "from app.a import A as A\nfrom app.b import B as B\nfrom app.c import C as C\n",
+		},
+		{
+			name:    "depois de bloco entre parênteses",
+			content: "from app.a import (\n    A,\n)\n\nx = 1\n",
+			stmt:    "from app.b import B",
+			want:    "from app.a import (\n    A,\n)\nfrom app.b import B\n\nx = 1\n",
+		},
+		{
+			name:    "nova seção depois de terceiros",
+			content: "from temporalio import activity\n\nx = 1\n",
+			stmt:    "from app.b import B",
+			want:    "from temporalio import activity\n\nfrom app.b import B\n\nx = 1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := insertImport(tt.content, tt.stmt); got != tt.want {
+				t.Errorf("insertImport() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatImportWrapsLongLines(t *testing.T) {
+	module := "project." + strings.Repeat("very_long_package_name.", 3) + "module"
+	got := formatImport(module, "SomeVeryLongSymbolName")
+	want := "from " + module + " import (\n    SomeVeryLongSymbolName,\n)"
+	if got != want {
+		t.Errorf("formatImport() = %q, want %q", got, want)
+	}
+}
+
+func TestNewComponentContextRejectsKeywords(t *testing.T) {
+	for _, name := range []string{"class", "None", "none", "async"} {
+		if _, err := newComponentContext("demo", name); err == nil {
+			t.Errorf("newComponentContext(%q) aceitou uma palavra reservada", name)
+		}
+	}
+	if _, err := newComponentContext("demo", "SendEmail"); err != nil {
+		t.Errorf("newComponentContext(SendEmail) = %v", err)
+	}
+}
+
+func TestAddComponentFailureWritesNothing(t *testing.T) {
+	root := renderTestProject(t)
+
+	workerFile := filepath.Join(root, "demo", "temporal", "worker", "main.py")
+	worker, err := os.ReadFile(workerFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	broken := strings.Replace(string(worker), "activities=[", "activity_list=[", 1)
+	if err := os.WriteFile(workerFile, []byte(broken), 0644); err != nil {
+		t.Fatal(err)
+	}
+	initFile := filepath.Join(root, "demo", "temporal", "activities", "__init__.py")
+	initBefore, _ := os.ReadFile(initFile)
+
+	var kind componentKind
+	for _, k := range componentKinds {
+		if k.name == "activity" {
+			kind = k
+		}
+	}
+	ctx, _ := newComponentContext("demo", "send_email")
+	if _, err := addComponent(root, kind, ctx, false); err == nil {
+		t.Fatal("addComponent deveria falhar sem a lista activities")
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "demo", "temporal", "activities", "send_email.py")); !os.IsNotExist(err) {
+		t.Error("arquivo do componente criado apesar da falha")
+	}
+	if initAfter, _ := os.ReadFile(initFile); string(initAfter) != string(initBefore) {
+		t.Errorf("__init__.py alterado apesar da falha:\n%s", initAfter)
+	}
+}
+
+func TestApplyFileEditsRollsBack(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.py")
+	if err := os.WriteFile(existing, []byte("old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	created := filepath.Join(dir, "created.py")
+
+	err := applyFileEdits([]fileEdit{
+		{path: existing, content: []byte("new\n")},
+		{path: created, content: []byte("x\n")},
+		{path: filepath.Join(dir, "missing", "file.py"), content: []byte("x\n")},
+	})
+	if err == nil {
+		t.Fatal("applyFileEdits deveria falhar")
+	}
+	if data, _ := os.ReadFile(existing); string(data) != "old\n" {
+		t.Errorf("existing.py = %q, esperado o conteúdo original", data)
+	}
+	if _, err := os.Stat(created); !os.IsNotExist(err) {
+		t.Error("created.py deveria ter sido removido")
+	}
+}
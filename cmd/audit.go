@@ -0,0 +1,457 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"github.com/algarys/algarys_cli/cmd/ui"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditOrg    string
+	auditPolicy string
+	auditFormat string
+)
+
+// Regras verificadas em cada repositório, na ordem das colunas da tabela
+const (
+	auditRuleset       = "ruleset"
+	auditCI            = "ci"
+	auditPyproject     = "pyproject"
+	auditPythonVersion = "python-version"
+	auditManifest      = "manifest"
+)
+
+var auditRules = []string{auditRuleset, auditCI, auditPyproject, auditPythonVersion, auditManifest}
+
+// Lint do template: todo projeto deve selecionar pelo menos estas regras do ruff
+var auditRuffRules = []string{"E", "F", "I", "N", "W", "UP", "B", "C4", "SIM"}
+
+// Repositórios auditados em paralelo
+const auditConcurrency = 4
+
+var auditCmd = &cobra.Command{
+	Use:   "audit [repo...]",
+	Short: "Verifica se os repositórios da org seguem os padrões",
+	Long: `Audita os repositórios de projetos da org (os que seguem o repo_pattern,
+ex: algarys_*) ou só os informados e mostra, por repositório, quais regras
+passam:
+
+  ruleset         ruleset da política aplicado e sem regras mais fracas
+  ci              workflow de CI da política presente
+  pyproject       pyproject.toml com as convenções do template
+  python-version  .python-version suportado e igual ao do pyproject
+  manifest        .algarys.toml válido e repositório com o nome do padrão
+
+Sai com código 1 se algum repositório violar alguma regra.
+
+Exemplos:
+  algarys audit
+  algarys audit algarys_meu-projeto --format json`,
+	Run: runAudit,
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.Flags().StringVar(&auditOrg, "org", cliConfig.Org, "Organização do GitHub")
+	auditCmd.Flags().StringVar(&auditPolicy, "policy", "", "Arquivo YAML da política de ruleset (padrão: a embutida no CLI)")
+	auditCmd.Flags().StringVar(&auditFormat, "format", "table", "Formato do relatório: table ou json")
+}
+
+// AuditCheck é o resultado de uma regra num repositório
+type AuditCheck struct {
+	Rule    string   `json:"rule"`
+	Passed  bool     `json:"passed"`
+	Details []string `json:"details,omitempty"`
+}
+
+// RepoAudit reúne as regras verificadas num repositório
+type RepoAudit struct {
+	Repo   string       `json:"repo"`
+	Passed bool         `json:"passed"`
+	Checks []AuditCheck `json:"checks"`
+}
+
+// AuditReport é o relatório da org, também usado na saída JSON
+type AuditReport struct {
+	Org        string      `json:"org"`
+	Policy     string      `json:"policy"`
+	Repos      []RepoAudit `json:"repos"`
+	Violations int         `json:"violations"`
+}
+
+func runAudit(cmd *cobra.Command, args []string) {
+	if auditFormat != "table" && auditFormat != "json" {
+		exitWithError(fmt.Sprintf("formato inválido: %s (use table ou json)", auditFormat))
+	}
+	asJSON := auditFormat == "json"
+
+	policy, err := loadRulesetPolicy(auditPolicy)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	if err := requireGitHubCLI(); err != nil {
+		exitWithError(err.Error())
+	}
+	api := ghCLI{}
+
+	repos := args
+	if len(repos) == 0 {
+		var spinner *ui.Spinner
+		if !asJSON {
+			fmt.Println()
+			spinner = ui.NewSpinner(ui.IconGitHub + "  Listando repositórios de " + auditOrg)
+			spinner.Start()
+		}
+		repos, err = listOrgRepos(api, auditOrg)
+		if err != nil {
+			if spinner != nil {
+				spinner.Error("Não foi possível listar os repositórios")
+			}
+			exitWithError(err.Error())
+		}
+		if spinner != nil {
+			spinner.Success(fmt.Sprintf("%d repositório(s) encontrados", len(repos)))
+		}
+	}
+
+	var spinner *ui.Spinner
+	if !asJSON {
+		spinner = ui.NewSpinner(ui.IconGear + "  Auditando")
+		spinner.Start()
+	}
+	report := auditRepos(api, auditOrg, repos, policy)
+	if spinner != nil {
+		spinner.Stop()
+	}
+
+	if asJSON {
+		data, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		printAuditReport(report)
+	}
+	if report.Violations > 0 {
+		os.Exit(1)
+	}
+}
+
+// Repositórios por página na listagem da org (máximo da API)
+const auditPageSize = 100
+
+// listOrgRepos lista os repositórios não arquivados da org com nome no
+// padrão dos projetos (prefixo e sufixo do repo_pattern)
+func listOrgRepos(api githubAPI, org string) ([]string, error) {
+	prefix, suffix := cliConfig.repoNameAffixes(org)
+	var names []string
+	for page := 1; ; page++ {
+		var repos []struct {
+			Name     string `json:"name"`
+			Archived bool   `json:"archived"`
+		}
+		if err := api.get(fmt.Sprintf("orgs/%s/repos?per_page=%d&page=%d", org, auditPageSize, page), &repos); err != nil {
+			return nil, err
+		}
+		for _, r := range repos {
+			if !r.Archived && strings.HasPrefix(r.Name, prefix) && strings.HasSuffix(r.Name, suffix) {
+				names = append(names, r.Name)
+			}
+		}
+		if len(repos) < auditPageSize {
+			return names, nil
+		}
+	}
+}
+
+// auditRepos audita os repositórios em paralelo, mantendo a ordem
+func auditRepos(api githubAPI, org string, repos []string, policy *RulesetPolicy) AuditReport {
+	report := AuditReport{Org: org, Policy: policy.Name, Repos: make([]RepoAudit, len(repos))}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, auditConcurrency)
+	for i, arg := range repos {
+		wg.Add(1)
+		go func(i int, arg string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			repoOrg, repoName := splitRepoArg(arg, org)
+			report.Repos[i] = auditRepo(api, repoOrg, repoName, policy)
+			if repoOrg != org {
+				report.Repos[i].Repo = repoOrg + "/" + repoName
+			}
+		}(i, arg)
+	}
+	wg.Wait()
+
+	for _, r := range report.Repos {
+		if !r.Passed {
+			report.Violations++
+		}
+	}
+	return report
+}
+
+// auditRepo verifica todas as regras num repositório
+func auditRepo(api githubAPI, org, repoName string, policy *RulesetPolicy) RepoAudit {
+	fullName := org + "/" + repoName
+	audit := RepoAudit{Repo: repoName, Passed: true}
+	add := func(rule string, details ...string) {
+		audit.Checks = append(audit.Checks, AuditCheck{Rule: rule, Passed: len(details) == 0, Details: details})
+		if len(details) > 0 {
+			audit.Passed = false
+		}
+	}
+
+	workflow := policy.StatusChecks.Workflow
+	if workflow == "" {
+		workflow = ".github/workflows/ci.yml"
+	}
+	_, workflowErr := fetchRepoFile(api, fullName, workflow)
+	pyproject, pyprojectErr := fetchRepoFile(api, fullName, "pyproject.toml")
+	pythonVersion, pythonVersionErr := fetchRepoFile(api, fullName, ".python-version")
+	manifest, manifestErr := fetchRepoFile(api, fullName, projectManifestFile)
+
+	add(auditRuleset, auditRulesetDrift(api, repoName, org, policy, workflowErr == nil)...)
+
+	if workflowErr != nil {
+		add(auditCI, missingFileDetail(workflow, workflowErr))
+	} else {
+		add(auditCI)
+	}
+
+	var py pyprojectFile
+	if pyprojectErr != nil {
+		add(auditPyproject, missingFileDetail("pyproject.toml", pyprojectErr))
+	} else if _, err := toml.Decode(pyproject, &py); err != nil {
+		add(auditPyproject, fmt.Sprintf("pyproject.toml inválido: %v", err))
+	} else {
+		add(auditPyproject, py.violations()...)
+	}
+
+	// O manifesto também informa a versão do Python esperada
+	var m ProjectManifest
+	var manifestDetails []string
+	manifestOK := false
+	if manifestErr != nil {
+		manifestDetails = []string{missingFileDetail(projectManifestFile, manifestErr)}
+	} else if _, err := toml.Decode(manifest, &m); err != nil {
+		manifestDetails = []string{fmt.Sprintf("%s inválido: %v", projectManifestFile, err)}
+	} else {
+		manifestOK = true
+		manifestDetails = manifestViolations(&m, org, repoName)
+	}
+
+	if pythonVersionErr != nil {
+		add(auditPythonVersion, missingFileDetail(".python-version", pythonVersionErr))
+	} else {
+		// .python-version pode fixar o patch (3.12.1); o resto só usa major.minor
+		var details []string
+		version := pythonMinorVersion(strings.TrimSpace(pythonVersion))
+		if err := validatePythonVersion(version); err != nil {
+			details = append(details, err.Error())
+		}
+		if pyprojectErr == nil && py.Project.RequiresPython != "" && py.Project.RequiresPython != ">="+version {
+			details = append(details, fmt.Sprintf("requires-python %s (esperado >=%s)", py.Project.RequiresPython, version))
+		}
+		if pyprojectErr == nil && py.Tool.Mypy.PythonVersion != "" && py.Tool.Mypy.PythonVersion != version {
+			details = append(details, fmt.Sprintf("mypy python_version %s (esperado %s)", py.Tool.Mypy.PythonVersion, version))
+		}
+		if manifestOK && m.Project.PythonVersion != "" && m.Project.PythonVersion != version {
+			details = append(details, fmt.Sprintf("%s registra Python %s", projectManifestFile, m.Project.PythonVersion))
+		}
+		add(auditPythonVersion, details...)
+	}
+	add(auditManifest, manifestDetails...)
+	return audit
+}
+
+// auditRulesetDrift compara o ruleset do repositório com a política
+func auditRulesetDrift(api githubAPI, repoName, org string, policy *RulesetPolicy, hasWorkflow bool) []string {
+	id, err := findRuleset(api, repoName, org, policy.Name)
+	if err != nil {
+		return []string{fmt.Sprintf("erro ao listar rulesets: %v", err)}
+	}
+	if id == "" {
+		return []string{fmt.Sprintf("ruleset '%s' não encontrado", policy.Name)}
+	}
+	rs, err := fetchRuleset(api, repoName, org, id)
+	if err != nil {
+		return []string{fmt.Sprintf("erro ao ler o ruleset: %v", err)}
+	}
+	return policy.drift(rs, policy.requiredChecks(hasWorkflow))
+}
+
+// fetchRepoFile lê um arquivo da branch padrão do repositório
+func fetchRepoFile(api githubAPI, fullName, path string) (string, error) {
+	var file struct {
+		Content string `json:"content"`
+	}
+	if err := api.get(fmt.Sprintf("repos/%s/contents/%s", fullName, path), &file); err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+	if err != nil {
+		return "", fmt.Errorf("conteúdo inválido: %v", err)
+	}
+	return string(data), nil
+}
+
+// pythonMinorVersion reduz uma versão do Python a major.minor (3.12.1 -> 3.12)
+func pythonMinorVersion(v string) string {
+	if parts := strings.SplitN(v, ".", 3); len(parts) == 3 {
+		return parts[0] + "." + parts[1]
+	}
+	return v
+}
+
+func missingFileDetail(path string, err error) string {
+	if isNotFound(err) {
+		return path + " não encontrado"
+	}
+	return fmt.Sprintf("erro ao ler %s: %v", path, err)
+}
+
+// pyprojectFile são os campos do pyproject.toml que o audit confere
+type pyprojectFile struct {
+	Project struct {
+		Name           string `toml:"name"`
+		RequiresPython string `toml:"requires-python"`
+	} `toml:"project"`
+	BuildSystem struct {
+		BuildBackend string `toml:"build-backend"`
+	} `toml:"build-system"`
+	Tool struct {
+		Ruff struct {
+			LineLength int `toml:"line-length"`
+			Lint       struct {
+				Select []string `toml:"select"`
+			} `toml:"lint"`
+		} `toml:"ruff"`
+		Mypy struct {
+			Strict        bool   `toml:"strict"`
+			PythonVersion string `toml:"python_version"`
+		} `toml:"mypy"`
+		Pytest struct {
+			IniOptions map[string]any `toml:"ini_options"`
+		} `toml:"pytest"`
+	} `toml:"tool"`
+}
+
+// violations confere as convenções do pyproject.toml gerado pelo template
+func (p *pyprojectFile) violations() []string {
+	var details []string
+	if p.Project.Name == "" {
+		details = append(details, "[project] sem name")
+	}
+	if p.Project.RequiresPython == "" {
+		details = append(details, "[project] sem requires-python")
+	}
+	if p.BuildSystem.BuildBackend != "hatchling.build" {
+		details = append(details, "build-backend não é hatchling")
+	}
+	if p.Tool.Ruff.LineLength != 88 {
+		details = append(details, "ruff line-length diferente de 88")
+	}
+	var missing []string
+	for _, rule := range auditRuffRules {
+		if !containsString(p.Tool.Ruff.Lint.Select, rule) {
+			missing = append(missing, rule)
+		}
+	}
+	if len(missing) > 0 {
+		details = append(details, "ruff sem as regras "+strings.Join(missing, ", "))
+	}
+	if !p.Tool.Mypy.Strict {
+		details = append(details, "mypy sem strict = true")
+	}
+	if len(p.Tool.Pytest.IniOptions) == 0 {
+		details = append(details, "sem [tool.pytest.ini_options]")
+	}
+	return details
+}
+
+// manifestViolations confere o .algarys.toml de um repositório
+func manifestViolations(m *ProjectManifest, org, repoName string) []string {
+	var details []string
+	if m.Project.Name == "" || m.Module == "" {
+		details = append(details, "sem o nome do projeto ou o pacote")
+	} else if expected := cliConfig.repoName(org, m.Project.Name); expected != repoName {
+		details = append(details, fmt.Sprintf("projeto %s deveria estar em %s", m.Project.Name, expected))
+	}
+	if m.Template.ID == "" {
+		details = append(details, "sem o template")
+	}
+	if m.CLIVersion == "" {
+		details = append(details, "sem cli_version")
+	}
+	return details
+}
+
+func printAuditReport(report AuditReport) {
+	if len(report.Repos) == 0 {
+		fmt.Println(ui.RenderWarning("Nenhum repositório para auditar"))
+		fmt.Println()
+		return
+	}
+
+	repoWidth := len("Repositório")
+	for _, r := range report.Repos {
+		repoWidth = max(repoWidth, len(r.Repo))
+	}
+	repoStyle := lipgloss.NewStyle().Width(repoWidth + 2)
+	headerStyle := lipgloss.NewStyle().Foreground(ui.TextDim).Bold(true)
+	colStyle := lipgloss.NewStyle().Width(len(auditPythonVersion) + 2)
+
+	header := repoStyle.Render("Repositório")
+	for _, rule := range auditRules {
+		header += colStyle.Render(rule)
+	}
+	fmt.Println()
+	fmt.Println("  " + headerStyle.Render(header))
+
+	for _, r := range report.Repos {
+		line := repoStyle.Render(r.Repo)
+		for _, c := range r.Checks {
+			icon := ui.SuccessStyle.Render(ui.IconSuccess)
+			if !c.Passed {
+				icon = ui.ErrorStyle.Render(ui.IconError)
+			}
+			line += colStyle.Render(icon)
+		}
+		fmt.Println("  " + line)
+	}
+	fmt.Println()
+
+	if report.Violations == 0 {
+		fmt.Println(ui.RenderSuccess(fmt.Sprintf("%d repositório(s) dentro do padrão", len(report.Repos))))
+		fmt.Println()
+		return
+	}
+
+	titleStyle := lipgloss.NewStyle().Foreground(ui.Primary).Bold(true).PaddingLeft(2)
+	ruleStyle := lipgloss.NewStyle().Foreground(ui.TextDim).Width(len(auditPythonVersion) + 2)
+	fmt.Println(titleStyle.Render("Violações:"))
+	for _, r := range report.Repos {
+		if r.Passed {
+			continue
+		}
+		fmt.Println()
+		fmt.Println("    " + lipgloss.NewStyle().Bold(true).Render(r.Repo))
+		for _, c := range r.Checks {
+			for _, d := range c.Details {
+				fmt.Printf("      %s %s %s\n", ui.ErrorStyle.Render(ui.IconError), ruleStyle.Render(c.Rule), d)
+			}
+		}
+	}
+	fmt.Println()
+	fmt.Println(ui.RenderError(fmt.Sprintf("%d de %d repositório(s) fora do padrão", report.Violations, len(report.Repos))))
+	fmt.Println()
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// restAPI lê a API de um servidor de teste, no lugar do gh
+type restAPI struct{ baseURL string }
+
+func (a restAPI) get(path string, v any) error {
+	resp, err := http.Get(a.baseURL + "/" + path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// fakeGitHub responde com JSON fixo por caminho (sem a query) e 404 no resto
+func fakeGitHub(t *testing.T, routes map[string]any) githubAPI {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(srv.Close)
+	return restAPI{baseURL: srv.URL}
+}
+
+// auditFixture são as rotas de um repositório gerado pelo init e com o
+// ruleset da política aplicado
+func auditFixture(t *testing.T, org, repoName string, policy *RulesetPolicy) map[string]any {
+	t.Helper()
+
+	tpl, err := resolveTemplate("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := ProjectConfig{Name: "demo", PythonVersion: "3.12", Modules: moduleKeys()}
+	files, err := generateProjectFiles(config, "demo", tpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := policy.rulesetJSON(org, policy.requiredChecks(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ruleset any
+	if err := json.Unmarshal([]byte(payload), &ruleset); err != nil {
+		t.Fatal(err)
+	}
+
+	prefix := fmt.Sprintf("/repos/%s/%s/", org, repoName)
+	routes := map[string]any{
+		prefix + "rulesets":   []map[string]any{{"id": 7, "source_type": "Repository", "name": policy.Name}},
+		prefix + "rulesets/7": ruleset,
+	}
+	for _, f := range files {
+		routes[prefix+"contents/"+f.Path] = map[string]string{"content": base64.StdEncoding.EncodeToString(f.Content)}
+	}
+	return routes
+}
+
+func setRepoFile(routes map[string]any, org, repoName, path, content string) {
+	routes[fmt.Sprintf("/repos/%s/%s/contents/%s", org, repoName, path)] = map[string]string{
+		"content": base64.StdEncoding.EncodeToString([]byte(content)),
+	}
+}
+
+func TestAuditRepoRules(t *testing.T) {
+	policy, err := loadRulesetPolicy("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	org := "algarys"
+	repoName := cliConfig.repoName(org, "demo")
+	prefix := fmt.Sprintf("/repos/%s/%s/", org, repoName)
+
+	tests := []struct {
+		name   string
+		change func(routes map[string]any)
+		failed []string // regras que devem falhar
+	}{
+		{
+			name:   "projeto gerado pelo init",
+			change: func(map[string]any) {},
+		},
+		{
+			name:   "sem ruleset",
+			change: func(r map[string]any) { r[prefix+"rulesets"] = []any{} },
+			failed: []string{auditRuleset},
+		},
+		{
+			name: "ruleset mais fraco que a política",
+			change: func(r map[string]any) {
+				r[prefix+"rulesets/7"] = map[string]any{"enforcement": "evaluate"}
+			},
+			failed: []string{auditRuleset},
+		},
+		{
+			name:   "sem workflow de CI",
+			change: func(r map[string]any) { delete(r, prefix+"contents/.github/workflows/ci.yml") },
+			failed: []string{auditCI},
+		},
+		{
+			name: "pyproject sem mypy strict",
+			change: func(r map[string]any) {
+				setRepoFile(r, org, repoName, "pyproject.toml", `[project]
+name = "demo"
+requires-python = ">=3.12"
+
+[build-system]
+build-backend = "hatchling.build"
+`)
+			},
+			failed: []string{auditPyproject},
+		},
+		{
+			name:   "python-version com patch",
+			change: func(r map[string]any) { setRepoFile(r, org, repoName, ".python-version", "3.12.1\n") },
+		},
+		{
+			name:   "python-version não suportada",
+			change: func(r map[string]any) { setRepoFile(r, org, repoName, ".python-version", "3.9\n") },
+			failed: []string{auditPythonVersion},
+		},
+		{
+			name:   "sem manifesto",
+			change: func(r map[string]any) { delete(r, prefix+"contents/"+projectManifestFile) },
+			failed: []string{auditManifest},
+		},
+		{
+			name: "manifesto de outro projeto",
+			change: func(r map[string]any) {
+				setRepoFile(r, org, repoName, projectManifestFile, `module = "other"
+cli_version = "1.0.0"
+
+[project]
+name = "other"
+python = "3.12"
+
+[template]
+id = "default"
+`)
+			},
+			failed: []string{auditManifest},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routes := auditFixture(t, org, repoName, policy)
+			tt.change(routes)
+
+			audit := auditRepo(fakeGitHub(t, routes), org, repoName, policy)
+			if len(audit.Checks) != len(auditRules) {
+				t.Fatalf("%d regras verificadas, esperado %d", len(audit.Checks), len(auditRules))
+			}
+			for i, c := range audit.Checks {
+				if c.Rule != auditRules[i] {
+					t.Errorf("regra %d = %s, esperado %s", i, c.Rule, auditRules[i])
+				}
+				if wantFail := containsString(tt.failed, c.Rule); c.Passed == wantFail {
+					t.Errorf("%s: passed = %v, detalhes: %v", c.Rule, c.Passed, c.Details)
+				}
+			}
+			if audit.Passed != (len(tt.failed) == 0) {
+				t.Errorf("Passed = %v", audit.Passed)
+			}
+		})
+	}
+}
+
+func TestListOrgRepos(t *testing.T) {
+	org := "algarys"
+	api := fakeGitHub(t, map[string]any{
+		"/orgs/algarys/repos": []map[string]any{
+			{"name": cliConfig.repoName(org, "billing")},
+			{"name": cliConfig.repoName(org, "legacy"), "archived": true},
+			{"name": "website"},
+		},
+	})
+
+	repos, err := listOrgRepos(api, org)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := cliConfig.repoName(org, "billing"); strings.Join(repos, ",") != want {
+		t.Errorf("listOrgRepos() = %v, want [%s]", repos, want)
+	}
+}
+
+func TestPythonMinorVersion(t *testing.T) {
+	for in, want := range map[string]string{"3.12": "3.12", "3.12.1": "3.12", "3": "3"} {
+		if got := pythonMinorVersion(in); got != want {
+			t.Errorf("pythonMinorVersion(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,432 @@
+package cmd
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/algarys/algarys_cli/cmd/ui"
+	"gopkg.in/yaml.v3"
+)
+
+// Bootstrap padrão dos repositórios novos, versionado junto com o CLI
+//
+//go:embed policies/bootstrap.yaml
+var defaultBootstrapConfig []byte
+
+// Templates de issue/PR embutidos, copiados para .github/
+//
+//go:embed policies/github
+var defaultGitHubTemplates embed.FS
+
+// BootstrapConfig descreve o que é configurado num repositório recém-criado
+type BootstrapConfig struct {
+	Version      int                `yaml:"version"`
+	Repository   RepositorySettings `yaml:"repository"`
+	Topics       []string           `yaml:"topics"`
+	Teams        []TeamPermission   `yaml:"teams"`
+	Labels       []LabelConfig      `yaml:"labels"`
+	Templates    bool               `yaml:"templates"`
+	TemplatesDir string             `yaml:"templates_dir"` // relativo ao arquivo de configuração
+	Reviewers    []string           `yaml:"reviewers"`
+
+	baseDir string
+}
+
+// RepositorySettings são as opções do PATCH /repos/{owner}/{repo}. Chaves
+// ausentes no YAML não são enviadas.
+type RepositorySettings struct {
+	DeleteBranchOnMerge      *bool  `yaml:"delete_branch_on_merge" json:"delete_branch_on_merge,omitempty"`
+	AllowSquashMerge         *bool  `yaml:"allow_squash_merge" json:"allow_squash_merge,omitempty"`
+	AllowMergeCommit         *bool  `yaml:"allow_merge_commit" json:"allow_merge_commit,omitempty"`
+	AllowRebaseMerge         *bool  `yaml:"allow_rebase_merge" json:"allow_rebase_merge,omitempty"`
+	AllowAutoMerge           *bool  `yaml:"allow_auto_merge" json:"allow_auto_merge,omitempty"`
+	SquashMergeCommitTitle   string `yaml:"squash_merge_commit_title" json:"squash_merge_commit_title,omitempty"`
+	SquashMergeCommitMessage string `yaml:"squash_merge_commit_message" json:"squash_merge_commit_message,omitempty"`
+}
+
+type TeamPermission struct {
+	Team       string `yaml:"team"` // slug do time na org
+	Permission string `yaml:"permission"`
+}
+
+type LabelConfig struct {
+	Name        string `yaml:"name"`
+	Color       string `yaml:"color"`
+	Description string `yaml:"description"`
+}
+
+var (
+	teamPermissions = setOf("pull", "triage", "push", "maintain", "admin")
+	labelColorRe    = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+)
+
+// loadBootstrapConfig lê a configuração de path ou, vazio, a embutida no CLI
+func loadBootstrapConfig(path string) (*BootstrapConfig, error) {
+	data := defaultBootstrapConfig
+	source := "bootstrap embutido"
+	var baseDir string
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("erro ao ler o bootstrap: %v", err)
+		}
+		source = path
+		baseDir = filepath.Dir(path)
+	}
+
+	var c BootstrapConfig
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("%s inválido: %v", source, err)
+	}
+	c.baseDir = baseDir
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", source, err)
+	}
+	return &c, nil
+}
+
+func (c *BootstrapConfig) validate() error {
+	if c.Version != 1 {
+		return fmt.Errorf("versão do bootstrap não suportada: %d", c.Version)
+	}
+	for _, t := range c.Teams {
+		if t.Team == "" {
+			return fmt.Errorf("teams: 'team' sem o slug do time")
+		}
+		if !teamPermissions[t.Permission] {
+			return fmt.Errorf("teams: permissão inválida para %s: '%s' (use pull, triage, push, maintain ou admin)", t.Team, t.Permission)
+		}
+	}
+	for i, l := range c.Labels {
+		if l.Name == "" {
+			return fmt.Errorf("labels: label sem nome")
+		}
+		c.Labels[i].Color = strings.TrimPrefix(l.Color, "#")
+		if !labelColorRe.MatchString(c.Labels[i].Color) {
+			return fmt.Errorf("labels: cor inválida para %s: '%s' (use hex, ex: d73a4a)", l.Name, l.Color)
+		}
+	}
+	for _, r := range c.Reviewers {
+		if !strings.Contains(r, "@") {
+			return fmt.Errorf("reviewers: '%s' não é @usuario, @org/time nem e-mail", r)
+		}
+	}
+	if c.TemplatesDir != "" {
+		c.Templates = true
+	}
+	return nil
+}
+
+// templatesFS são os arquivos copiados para .github/
+func (c *BootstrapConfig) templatesFS() (fs.FS, error) {
+	if c.TemplatesDir == "" {
+		return fs.Sub(defaultGitHubTemplates, "policies/github")
+	}
+	dir := c.TemplatesDir
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(c.baseDir, dir)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("templates_dir não encontrado: %s", dir)
+	}
+	return os.DirFS(dir), nil
+}
+
+// describe resume o que o bootstrap configura num repositório da org
+func (c *BootstrapConfig) describe(org string) string {
+	var parts []string
+	if c.Repository != (RepositorySettings{}) {
+		parts = append(parts, c.Repository.describe())
+	}
+	if len(c.Topics) > 0 {
+		parts = append(parts, "topics "+strings.Join(c.topics(org), ", "))
+	}
+	if len(c.Teams) > 0 {
+		parts = append(parts, fmt.Sprintf("%d time(s)", len(c.Teams)))
+	}
+	if len(c.Labels) > 0 {
+		parts = append(parts, fmt.Sprintf("%d labels", len(c.Labels)))
+	}
+	if c.Templates {
+		parts = append(parts, "templates de issue/PR")
+	}
+	if len(c.Reviewers) > 0 {
+		parts = append(parts, "CODEOWNERS")
+	}
+	if len(parts) == 0 {
+		return "nada a configurar"
+	}
+	return strings.Join(parts, " + ")
+}
+
+// topics aplica {org} nos topics (o GitHub só aceita minúsculas)
+func (c *BootstrapConfig) topics(org string) []string {
+	topics := make([]string, 0, len(c.Topics))
+	for _, t := range c.Topics {
+		topics = append(topics, strings.ReplaceAll(t, "{org}", strings.ToLower(org)))
+	}
+	return topics
+}
+
+func (s RepositorySettings) describe() string {
+	var methods []string
+	for _, m := range []struct {
+		name    string
+		allowed *bool
+	}{
+		{"squash", s.AllowSquashMerge},
+		{"merge commit", s.AllowMergeCommit},
+		{"rebase", s.AllowRebaseMerge},
+	} {
+		if m.allowed != nil && *m.allowed {
+			methods = append(methods, m.name)
+		}
+	}
+	desc := "opções do repositório"
+	if len(methods) > 0 {
+		desc = "merge só " + strings.Join(methods, "/")
+	}
+	if s.DeleteBranchOnMerge != nil && *s.DeleteBranchOnMerge {
+		desc += ", apaga branches"
+	}
+	return desc
+}
+
+// filesStep é a etapa que commita os templates de issue/PR e o CODEOWNERS
+// no projeto local; roda antes do push
+func (c *BootstrapConfig) filesStep(projectDir string) (initStep, bool) {
+	var what []string
+	if c.Templates {
+		what = append(what, "templates de issue/PR")
+	}
+	if len(c.Reviewers) > 0 {
+		what = append(what, "CODEOWNERS")
+	}
+	if len(what) == 0 {
+		return initStep{}, false
+	}
+
+	// No dry-run o projeto ainda não existe: todos os arquivos seriam gravados
+	var preview []string
+	if files, err := c.githubFiles(); err == nil {
+		for path := range files {
+			preview = append(preview, ".github/"+path)
+		}
+		sort.Strings(preview)
+	}
+
+	return initStep{
+		icon:     ui.IconFile,
+		message:  "Adicionando " + strings.Join(what, " e "),
+		optional: true,
+		commands: bootstrapCommitCommands(preview),
+		action: func() error {
+			written, err := c.writeGitHubFiles(projectDir)
+			if err != nil {
+				return err
+			}
+			if len(written) == 0 {
+				return fmt.Errorf("%w: arquivos já existem", errStepSkipped)
+			}
+			for _, args := range bootstrapCommitCommands(written) {
+				if err := runCommand(projectDir, nil, args); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}, true
+}
+
+// bootstrapCommitCommands commitam só os arquivos do bootstrap: outras
+// mudanças em .github ficam de fora
+func bootstrapCommitCommands(paths []string) [][]string {
+	return [][]string{
+		append([]string{"git", "add", "--"}, paths...),
+		append([]string{"git", "commit", "-q", "-m", "Add issue/PR templates and CODEOWNERS", "--"}, paths...),
+	}
+}
+
+// writeGitHubFiles grava os templates e o CODEOWNERS em .github/, sem
+// sobrescrever arquivos do projeto. Devolve os arquivos criados, relativos
+// ao projeto.
+func (c *BootstrapConfig) writeGitHubFiles(projectDir string) ([]string, error) {
+	files, err := c.githubFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+	for path, data := range files {
+		rel := ".github/" + path
+		dest := filepath.Join(projectDir, filepath.FromSlash(rel))
+		if _, err := os.Stat(dest); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return written, err
+		}
+		if err := os.WriteFile(dest, data, 0644); err != nil {
+			return written, err
+		}
+		written = append(written, rel)
+	}
+	sort.Strings(written)
+	return written, nil
+}
+
+// githubFiles são os templates e o CODEOWNERS do bootstrap, com caminhos
+// relativos a .github/
+func (c *BootstrapConfig) githubFiles() (map[string][]byte, error) {
+	files := map[string][]byte{}
+	if c.Templates {
+		fsys, err := c.templatesFS()
+		if err != nil {
+			return nil, err
+		}
+		err = fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			data, err := fs.ReadFile(fsys, path)
+			if err != nil {
+				return err
+			}
+			files[path] = data
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(c.Reviewers) > 0 {
+		files["CODEOWNERS"] = []byte("# Revisores pedidos automaticamente em todo PR\n* " + strings.Join(c.Reviewers, " ") + "\n")
+	}
+	return files, nil
+}
+
+// apiSteps são as etapas que configuram o repositório pela API; rodam
+// depois do push
+func (c *BootstrapConfig) apiSteps(org, repoName string) []initStep {
+	fullName := org + "/" + repoName
+	var steps []initStep
+
+	if c.Repository != (RepositorySettings{}) {
+		steps = append(steps, initStep{
+			icon:     ui.IconGear,
+			message:  "Configurando o repositório (" + c.Repository.describe() + ")",
+			optional: true,
+			commands: [][]string{ghAPIWriteCommand("PATCH", "repos/"+fullName)},
+			action: func() error {
+				payload, _ := json.Marshal(c.Repository)
+				return ghAPIWrite("PATCH", "repos/"+fullName, payload)
+			},
+		})
+	}
+
+	if topics := c.topics(org); len(topics) > 0 {
+		steps = append(steps, initStep{
+			icon:     ui.IconInfo,
+			message:  "Topics: " + strings.Join(topics, ", "),
+			optional: true,
+			commands: [][]string{ghAPIWriteCommand("PUT", "repos/"+fullName+"/topics")},
+			action: func() error {
+				payload, _ := json.Marshal(map[string][]string{"names": topics})
+				return ghAPIWrite("PUT", "repos/"+fullName+"/topics", payload)
+			},
+		})
+	}
+
+	if len(c.Teams) > 0 {
+		teamPath := func(t TeamPermission) string {
+			return fmt.Sprintf("orgs/%s/teams/%s/repos/%s", org, t.Team, fullName)
+		}
+		var names []string
+		var commands [][]string
+		for _, t := range c.Teams {
+			names = append(names, t.Team+": "+t.Permission)
+			commands = append(commands, ghAPIWriteCommand("PUT", teamPath(t)))
+		}
+		steps = append(steps, initStep{
+			icon:     ui.IconKey,
+			message:  "Permissões dos times (" + strings.Join(names, ", ") + ")",
+			optional: true,
+			commands: commands,
+			action: func() error {
+				var failed []string
+				for _, t := range c.Teams {
+					payload, _ := json.Marshal(map[string]string{"permission": t.Permission})
+					if err := ghAPIWrite("PUT", teamPath(t), payload); err != nil {
+						failed = append(failed, fmt.Sprintf("%s: %v", t.Team, err))
+					}
+				}
+				return joinStepErrors(failed)
+			},
+		})
+	}
+
+	if len(c.Labels) > 0 {
+		// Uma label que já existe é atualizada com PATCH no lugar do POST
+		commands := make([][]string, len(c.Labels))
+		for i := range c.Labels {
+			commands[i] = ghAPIWriteCommand("POST", "repos/"+fullName+"/labels")
+		}
+		steps = append(steps, initStep{
+			icon:     ui.IconStar,
+			message:  fmt.Sprintf("Labels (%d)", len(c.Labels)),
+			optional: true,
+			commands: commands,
+			action: func() error {
+				var failed []string
+				for _, l := range c.Labels {
+					if err := upsertLabel(fullName, l); err != nil {
+						failed = append(failed, fmt.Sprintf("%s: %v", l.Name, err))
+					}
+				}
+				return joinStepErrors(failed)
+			},
+		})
+	}
+
+	return steps
+}
+
+// upsertLabel cria a label ou, se já existir (HTTP 422), atualiza cor e descrição
+func upsertLabel(fullName string, l LabelConfig) error {
+	payload, _ := json.Marshal(map[string]string{"name": l.Name, "color": l.Color, "description": l.Description})
+	err := ghAPIWrite("POST", "repos/"+fullName+"/labels", payload)
+	if err == nil || !strings.Contains(err.Error(), "HTTP 422") {
+		return err
+	}
+	return ghAPIWrite("PATCH", "repos/"+fullName+"/labels/"+url.PathEscape(l.Name), payload)
+}
+
+// ghAPIWrite envia payload (JSON) para a API do GitHub com o método informado
+func ghAPIWrite(method, path string, payload []byte) error {
+	return runCommand("", strings.NewReader(string(payload)), ghAPIWriteCommand(method, path))
+}
+
+// ghAPIWriteCommand é o gh api que lê o payload (JSON) do stdin
+func ghAPIWriteCommand(method, path string) []string {
+	return []string{"gh", "api", path,
+		"-X", method,
+		"-H", "Accept: application/vnd.github+json",
+		"--input", "-",
+	}
+}
+
+// joinStepErrors junta as falhas parciais de uma etapa num único erro
+func joinStepErrors(failed []string) error {
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(failed, "; "))
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBootstrapFilesStepCommitsOnlyItsFiles(t *testing.T) {
+	for _, env := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(env, "test")
+	}
+	for _, env := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(env, "test@example.com")
+	}
+
+	project := t.TempDir()
+	gitTest(t, project, "init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(project, "README.md"), []byte("# demo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitTest(t, project, "add", ".")
+	gitTest(t, project, "commit", "-q", "-m", "init")
+
+	// Mudança do usuário em .github, que não faz parte do bootstrap
+	unrelated := filepath.Join(project, ".github", "workflows", "deploy.yml")
+	if err := os.MkdirAll(filepath.Dir(unrelated), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(unrelated, []byte("name: deploy\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := loadBootstrapConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Reviewers = []string{"@algarys/engenharia"}
+	step, ok := c.filesStep(project)
+	if !ok {
+		t.Fatal("filesStep sem arquivos para gravar")
+	}
+	if err := step.action(); err != nil {
+		t.Fatalf("filesStep: %v", err)
+	}
+
+	committed := gitTest(t, project, "show", "--name-only", "--format=", "HEAD")
+	if !strings.Contains(committed, ".github/CODEOWNERS") {
+		t.Errorf("CODEOWNERS fora do commit:\n%s", committed)
+	}
+	if strings.Contains(committed, "deploy.yml") {
+		t.Errorf("arquivo do usuário entrou no commit do bootstrap:\n%s", committed)
+	}
+	if status := gitTest(t, project, "status", "--porcelain"); !strings.Contains(status, "?? .github/workflows/") {
+		t.Errorf("arquivo do usuário deveria continuar fora do índice:\n%s", status)
+	}
+}
+
+func TestRepoSetupStepsListCommands(t *testing.T) {
+	c, err := loadBootstrapConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Reviewers = []string{"@algarys/engenharia"}
+	c.Teams = []TeamPermission{{Team: "engenharia", Permission: "push"}}
+
+	// O dry-run monta a prévia a partir destas etapas: nenhuma pode ficar sem comando
+	var lines []string
+	for _, step := range repoSetupSteps("demo", "algarys", "algarys_demo", c, nil) {
+		if len(step.commands) == 0 {
+			t.Errorf("etapa %q sem comandos para o dry-run", step.message)
+		}
+		for _, args := range step.commands {
+			lines = append(lines, shellJoin(args))
+		}
+	}
+
+	preview := strings.Join(lines, "\n")
+	for _, want := range []string{
+		"git commit -q -m 'Add issue/PR templates and CODEOWNERS' -- .github/CODEOWNERS",
+		"git push -q -u origin main",
+		"gh api repos/algarys/algarys_demo -X PATCH",
+		"gh api repos/algarys/algarys_demo/topics -X PUT",
+		"gh api orgs/algarys/teams/engenharia/repos/algarys/algarys_demo -X PUT",
+		"gh api repos/algarys/algarys_demo/labels -X POST",
+		"gh api /repos/algarys/algarys_demo/rulesets -X POST",
+	} {
+		if !strings.Contains(preview, want) {
+			t.Errorf("prévia sem %q:\n%s", want, preview)
+		}
+	}
+}
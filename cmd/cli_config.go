@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/algarys/algarys_cli/cmd/ui"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Arquivo de configuração do CLI, em ~/.algarys (ALGARYS_CONFIG troca o caminho)
+const cliConfigFile = "config.yaml"
+
+// CLIConfig são as convenções da org usadas por todos os comandos
+type CLIConfig struct {
+	Org         string `yaml:"org"`          // org padrão dos repositórios
+	RepoPattern string `yaml:"repo_pattern"` // nome do repositório: {org} e {name}
+	Visibility  string `yaml:"visibility"`   // private, internal ou public
+	ReleaseRepo string `yaml:"release_repo"` // owner/repo das releases do CLI
+}
+
+var visibilityLabels = map[string]string{"private": "privados", "internal": "internos", "public": "públicos"}
+
+var defaultCLIConfig = CLIConfig{
+	Org:         "algarys",
+	RepoPattern: "{org}_{name}",
+	Visibility:  "private",
+	ReleaseRepo: "algarys/algarys_cli",
+}
+
+// cliConfigKey liga cada chave do arquivo à variável de ambiente que a sobrescreve
+type cliConfigKey struct {
+	key   string
+	env   string
+	value func(c *CLIConfig) *string
+}
+
+var cliConfigKeys = []cliConfigKey{
+	{"org", "ALGARYS_ORG", func(c *CLIConfig) *string { return &c.Org }},
+	{"repo_pattern", "ALGARYS_REPO_PATTERN", func(c *CLIConfig) *string { return &c.RepoPattern }},
+	{"visibility", "ALGARYS_REPO_VISIBILITY", func(c *CLIConfig) *string { return &c.Visibility }},
+	{"release_repo", "ALGARYS_RELEASE_REPO", func(c *CLIConfig) *string { return &c.ReleaseRepo }},
+}
+
+// Resolvida uma vez, antes das flags: os defaults de --org já vêm daqui.
+// Um erro só é mostrado quando algum comando roda (rootCmd.PersistentPreRun);
+// config, version e update rodam mesmo assim, para o usuário poder corrigir.
+var cliConfig, cliConfigSources, cliConfigErr = loadCLIConfig()
+
+// loadCLIConfig resolve a configuração: padrão < arquivo < variáveis de
+// ambiente. Devolve também de onde veio cada chave.
+func loadCLIConfig() (CLIConfig, map[string]string, error) {
+	config := defaultCLIConfig
+	sources := map[string]string{}
+	for _, k := range cliConfigKeys {
+		sources[k.key] = "padrão"
+	}
+
+	path := cliConfigPath()
+	if data, err := os.ReadFile(path); err == nil {
+		var file CLIConfig
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return defaultCLIConfig, sources, fmt.Errorf("%s inválido: %v", path, err)
+		}
+		for _, k := range cliConfigKeys {
+			if v := strings.TrimSpace(*k.value(&file)); v != "" {
+				*k.value(&config) = v
+				sources[k.key] = path
+			}
+		}
+	} else if !os.IsNotExist(err) {
+		return defaultCLIConfig, sources, fmt.Errorf("erro ao ler %s: %v", path, err)
+	}
+
+	for _, k := range cliConfigKeys {
+		if v := strings.TrimSpace(os.Getenv(k.env)); v != "" {
+			*k.value(&config) = v
+			sources[k.key] = "$" + k.env
+		}
+	}
+
+	// Com erro, os valores vêm mesmo assim: o `algarys config` mostra o que está errado
+	return config, sources, config.validate()
+}
+
+func cliConfigPath() string {
+	if path := os.Getenv("ALGARYS_CONFIG"); path != "" {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(algarysDir, cliConfigFile)
+	}
+	return filepath.Join(homeDir, algarysDir, cliConfigFile)
+}
+
+func (c CLIConfig) validate() error {
+	if !strings.Contains(c.RepoPattern, "{name}") {
+		return fmt.Errorf("repo_pattern precisa de {name}: %s", c.RepoPattern)
+	}
+	switch c.Visibility {
+	case "private", "internal", "public":
+	default:
+		return fmt.Errorf("visibility inválida: %s (use private, internal ou public)", c.Visibility)
+	}
+	if owner, repo, ok := strings.Cut(c.ReleaseRepo, "/"); !ok || owner == "" || repo == "" {
+		return fmt.Errorf("release_repo deve ser owner/repo: %s", c.ReleaseRepo)
+	}
+	return nil
+}
+
+// repoName aplica o padrão de nome: {org}_{name} vira algarys_meu-projeto
+func (c CLIConfig) repoName(org, projectName string) string {
+	return strings.NewReplacer("{org}", org, "{name}", projectName).Replace(c.RepoPattern)
+}
+
+// repoNameAffixes são o prefixo e o sufixo que todo repositório da org tem
+// pelo padrão de nome (usados para listar os repositórios dos projetos)
+func (c CLIConfig) repoNameAffixes(org string) (prefix, suffix string) {
+	prefix, suffix, _ = strings.Cut(c.RepoPattern, "{name}")
+	r := strings.NewReplacer("{org}", org)
+	return r.Replace(prefix), r.Replace(suffix)
+}
+
+// requireCLIConfig para o comando se a configuração for inválida
+func requireCLIConfig(cmd *cobra.Command, args []string) {
+	if cliConfigErr != nil {
+		fmt.Println(ui.RenderError(cliConfigErr.Error()))
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.Muted).PaddingLeft(2).Render(
+			"Corrija o arquivo ou as variáveis ALGARYS_* (veja: algarys config)",
+		))
+		fmt.Println()
+		os.Exit(1)
+	}
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Mostra as convenções da org em uso (org, nome dos repositórios...)",
+	Long: `Mostra a configuração resolvida e de onde veio cada valor.
+
+Ordem de precedência: padrão < ~/.algarys/config.yaml < variáveis de ambiente
+(e, em cada comando, a flag --org):
+
+  org: algarys                    # ALGARYS_ORG
+  repo_pattern: "{org}_{name}"    # ALGARYS_REPO_PATTERN
+  visibility: private             # ALGARYS_REPO_VISIBILITY (private, internal, public)
+  release_repo: algarys/algarys_cli  # ALGARYS_RELEASE_REPO
+
+ALGARYS_CONFIG aponta para outro arquivo.`,
+	Args: cobra.NoArgs,
+	// Sobrescreve o requireCLIConfig: aqui o erro é mostrado junto com os valores
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+	Run: func(cmd *cobra.Command, args []string) {
+		if cliConfigErr != nil {
+			fmt.Println()
+			fmt.Println(ui.RenderError(cliConfigErr.Error()))
+		}
+		keyStyle := lipgloss.NewStyle().Foreground(ui.TextDim).Width(14)
+		valueStyle := lipgloss.NewStyle().Foreground(ui.Primary).Bold(true)
+		sourceStyle := lipgloss.NewStyle().Foreground(ui.Muted).Italic(true)
+
+		fmt.Println()
+		for _, k := range cliConfigKeys {
+			fmt.Printf("  %s %s  %s\n", keyStyle.Render(k.key), valueStyle.Render(*k.value(&cliConfig)), sourceStyle.Render("("+cliConfigSources[k.key]+")"))
+		}
+		fmt.Println()
+		fmt.Println(ui.RenderInfo(fmt.Sprintf("Arquivo: %s", cliConfigPath())))
+		fmt.Println(ui.RenderInfo(fmt.Sprintf("Exemplo de repositório: %s/%s", cliConfig.Org, cliConfig.repoName(cliConfig.Org, "meu-projeto"))))
+		fmt.Println()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	rootCmd.PersistentPreRun = requireCLIConfig
+}
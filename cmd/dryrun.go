@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/algarys/algarys_cli/cmd/ui"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fileTreeNode é um nó da árvore de arquivos do dry-run
+type fileTreeNode struct {
+	name     string
+	size     int
+	isFile   bool
+	children map[string]*fileTreeNode
+}
+
+// printInitDryRun mostra o que o `algarys init` geraria, sem criar nada
+func printInitDryRun(config ProjectConfig, moduleName string, files []RenderedFile, bootstrap *BootstrapConfig, showContents bool) {
+	header := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ui.Primary).
+		Render(fmt.Sprintf("  %s Dry-run: %s (nada será criado)", ui.IconInfo, config.Name))
+	fmt.Println(header)
+	fmt.Println()
+
+	titleStyle := lipgloss.NewStyle().Foreground(ui.Primary).Bold(true).PaddingLeft(2)
+
+	// Árvore de arquivos com tamanhos
+	fmt.Println(titleStyle.Render("Arquivos:"))
+	fmt.Println()
+	fmt.Println(lipgloss.NewStyle().PaddingLeft(4).Render(renderFileTree(config.Name, files)))
+
+	total := 0
+	for _, f := range files {
+		total += len(f.Content)
+	}
+	fmt.Println(lipgloss.NewStyle().Foreground(ui.TextDim).PaddingLeft(4).Render(
+		fmt.Sprintf("%d arquivos, %s", len(files), formatSize(total)),
+	))
+	fmt.Println()
+
+	if showContents {
+		fmt.Println(titleStyle.Render("Conteúdo:"))
+		fmt.Println()
+		for _, f := range files {
+			fmt.Println(lipgloss.NewStyle().Foreground(ui.Accent).Bold(true).PaddingLeft(4).Render(
+				fmt.Sprintf("── %s (%s) ──", f.Path, formatSize(len(f.Content))),
+			))
+			fmt.Println(string(f.Content))
+		}
+	}
+
+	// Comandos externos que seriam executados
+	fmt.Println(titleStyle.Render("Comandos que seriam executados:"))
+	fmt.Println()
+
+	cmdStyle := lipgloss.NewStyle().Foreground(ui.Text).PaddingLeft(4)
+	dirStyle := lipgloss.NewStyle().Foreground(ui.Muted)
+
+	var commands [][]string
+	commands = append(commands, localGitCommands()...)
+	commands = append(commands, uvSyncCommand())
+	policy, policyErr := loadRulesetPolicy("")
+	if config.CreateGitHub {
+		// As mesmas etapas que o setupGitHubRepo executa depois de criar o repositório
+		repoName := cliConfig.repoName(config.GitHubOrg, config.Name)
+		commands = append(commands, ghRepoCreateCommand(config.Name, config.Description, config.GitHubOrg))
+		for _, step := range repoSetupSteps(config.Name, config.GitHubOrg, repoName, bootstrap, nil) {
+			commands = append(commands, step.commands...)
+		}
+	}
+	if initVerify {
+		commands = append(commands, verifySyncCommand())
+		for _, check := range projectChecks(moduleName) {
+			commands = append(commands, check.args)
+		}
+	}
+
+	for _, args := range commands {
+		fmt.Println(cmdStyle.Render(dirStyle.Render(config.Name+"/ $ ") + shellJoin(args)))
+	}
+	if config.CreateGitHub && policyErr == nil {
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.Muted).Italic(true).PaddingLeft(6).Render(
+			fmt.Sprintf("(ruleset '%s': %s, JSON via stdin)", policy.Name, policy.describe(projectStatusChecks(policy, files))),
+		))
+	}
+	if bootstrap != nil {
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.Muted).Italic(true).PaddingLeft(6).Render(
+			fmt.Sprintf("(bootstrap do repositório: %s)", bootstrap.describe(config.GitHubOrg)),
+		))
+	}
+	fmt.Println()
+}
+
+// renderFileTree desenha os arquivos como árvore (pastas primeiro)
+func renderFileTree(rootName string, files []RenderedFile) string {
+	root := &fileTreeNode{name: rootName, children: map[string]*fileTreeNode{}}
+	for _, f := range files {
+		node := root
+		parts := strings.Split(f.Path, "/")
+		for i, part := range parts {
+			child, ok := node.children[part]
+			if !ok {
+				child = &fileTreeNode{name: part, children: map[string]*fileTreeNode{}}
+				node.children[part] = child
+			}
+			if i == len(parts)-1 {
+				child.isFile = true
+				child.size = len(f.Content)
+			}
+			node = child
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(rootName + "/\n")
+	writeTreeChildren(&b, root, "")
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func writeTreeChildren(b *strings.Builder, node *fileTreeNode, prefix string) {
+	children := make([]*fileTreeNode, 0, len(node.children))
+	for _, c := range node.children {
+		children = append(children, c)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		if children[i].isFile != children[j].isFile {
+			return !children[i].isFile
+		}
+		return children[i].name < children[j].name
+	})
+
+	for i, c := range children {
+		connector, nextPrefix := "├── ", prefix+"│   "
+		if i == len(children)-1 {
+			connector, nextPrefix = "└── ", prefix+"    "
+		}
+
+		if c.isFile {
+			b.WriteString(prefix + connector + c.name + ui.MutedStyle.Render(" ("+formatSize(c.size)+")") + "\n")
+			continue
+		}
+		b.WriteString(prefix + connector + c.name + "/\n")
+		writeTreeChildren(b, c, nextPrefix)
+	}
+}
+
+func formatSize(bytes int) string {
+	if bytes < 1024 {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	return fmt.Sprintf("%.1f KB", float64(bytes)/1024)
+}
+
+// shellJoin formata argumentos como uma linha de shell copiável
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if a == "" || strings.ContainsAny(a, " \t\"'$`\\*?;&|<>()") {
+			quoted[i] = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+		} else {
+			quoted[i] = a
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+// printHooksDryRun lista os hooks do template que rodariam, com o comando
+// de cada um
+func printHooksDryRun(projectName string, hooks *hookRunner, preGen, postGen []TemplateHook) {
+	if len(preGen)+len(postGen) == 0 {
+		return
+	}
+
+	fmt.Println(lipgloss.NewStyle().Foreground(ui.Primary).Bold(true).PaddingLeft(2).Render("Hooks do template:"))
+	fmt.Println()
+	printHookCommands(projectName, hooks, preGen, postGen)
+	if !trustedTemplate(hooks.tpl) {
+		fmt.Println(ui.RenderWarning("Template remoto: o init real pede confirmação antes de rodar os hooks (ou --yes)"))
+	}
+	fmt.Println()
+}
+
+// printHookCommands mostra o comando de cada hook, na ordem em que rodam
+func printHookCommands(projectName string, hooks *hookRunner, preGen, postGen []TemplateHook) {
+	cmdStyle := lipgloss.NewStyle().Foreground(ui.Text).PaddingLeft(4)
+	dirStyle := lipgloss.NewStyle().Foreground(ui.Muted)
+	for _, stage := range []struct {
+		name  string
+		hooks []TemplateHook
+	}{{hookPreGen, preGen}, {hookPostGen, postGen}} {
+		for _, hook := range stage.hooks {
+			line := hook.title()
+			if args, err := hooks.command(stage.name, hook); err == nil {
+				line = shellJoin(args)
+			}
+			if hook.Optional {
+				line += dirStyle.Render("  (opcional)")
+			}
+			fmt.Println(cmdStyle.Render(dirStyle.Render(fmt.Sprintf("[%s] %s/ $ ", stage.name, projectName)) + line))
+		}
+	}
+}
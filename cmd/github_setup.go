@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/algarys/algarys_cli/cmd/ui"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-isatty"
+)
+
+// setupGitHubRepo cria o repositório, envia o código, roda o bootstrap (se
+// houver) e aplica o ruleset da política. Falhas do bootstrap viram aviso;
+// se o push ou o ruleset falharem, oferece apagar o repositório.
+func setupGitHubRepo(config ProjectConfig, files []RenderedFile, bootstrap *BootstrapConfig) {
+	repoName := cliConfig.repoName(config.GitHubOrg, config.Name)
+	fullName := fmt.Sprintf("%s/%s", config.GitHubOrg, repoName)
+
+	spinner := ui.NewSpinner(ui.IconGitHub + "  Criando repositório no GitHub")
+	spinner.Start()
+	time.Sleep(300 * time.Millisecond)
+
+	if err := createGitHubRepo(config.Name, config.Name, config.Description, config.GitHubOrg); err != nil {
+		spinner.Warning(fmt.Sprintf("Repositório não criado: %v", err))
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.Muted).PaddingLeft(2).Render(
+			fmt.Sprintf("Para criar depois: cd %s && algarys repo create", config.Name),
+		))
+		return
+	}
+	spinner.Success(fmt.Sprintf("Repositório criado: github.com/%s", fullName))
+
+	var requiredChecks []string
+	policy, policyErr := loadRulesetPolicy("")
+	if policyErr == nil {
+		requiredChecks = projectStatusChecks(policy, files)
+	}
+
+	steps := repoSetupSteps(config.Name, config.GitHubOrg, repoName, bootstrap, func() error {
+		if policyErr != nil {
+			return policyErr
+		}
+		_, err := applyRuleset(repoName, config.GitHubOrg, policy, requiredChecks)
+		return err
+	})
+	err := runInitSteps(steps)
+	if err == nil {
+		fmt.Println(ui.RenderInfo(fmt.Sprintf("Ruleset '%s' configurado (%s)", policy.Name, policy.describe(requiredChecks))))
+		return
+	}
+
+	fmt.Println(ui.RenderWarning(err.Error()))
+	offerGitHubCleanup(config.Name, fullName)
+	fmt.Println(lipgloss.NewStyle().Foreground(ui.Muted).PaddingLeft(2).Render(
+		fmt.Sprintf("Para tentar de novo depois: cd %s && algarys repo create", config.Name),
+	))
+	fmt.Println()
+}
+
+// repoSetupSteps são as etapas depois de o repositório existir no GitHub:
+// arquivos do bootstrap (commit local), push, configurações pela API e, por
+// último, o ruleset (protect). Só o push e o ruleset são obrigatórios.
+func repoSetupSteps(projectDir, org, repoName string, bootstrap *BootstrapConfig, protect func() error) []initStep {
+	var steps []initStep
+	if bootstrap != nil {
+		if step, ok := bootstrap.filesStep(projectDir); ok {
+			steps = append(steps, step)
+		}
+	}
+	steps = append(steps, initStep{
+		icon:     ui.IconGit,
+		message:  "Enviando código para o GitHub",
+		commands: [][]string{gitPushCommand()},
+		action: func() error {
+			return runCommand(projectDir, nil, gitPushCommand())
+		},
+	})
+	if bootstrap != nil {
+		steps = append(steps, bootstrap.apiSteps(org, repoName)...)
+	}
+	return append(steps, initStep{
+		icon:     ui.IconLock,
+		message:  "Configurando regras de proteção",
+		commands: [][]string{rulesetAPICommand(repoName, org, "")},
+		action:   protect,
+	})
+}
+
+// offerGitHubCleanup pergunta se o repositório incompleto deve ser apagado.
+// Sem TTY (ou com --yes) nada é apagado: só mostra como fazer.
+func offerGitHubCleanup(projectDir, fullName string) {
+	deleteCmd := fmt.Sprintf("gh repo delete %s --yes", fullName)
+	hint := lipgloss.NewStyle().Foreground(ui.Primary).PaddingLeft(4)
+
+	if initYes || !isatty.IsTerminal(os.Stdin.Fd()) {
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.Muted).PaddingLeft(2).Render(
+			"O repositório ficou incompleto no GitHub. Para apagá-lo:",
+		))
+		fmt.Println(hint.Render(deleteCmd))
+		fmt.Println()
+		return
+	}
+
+	remove := false
+	confirm := huh.NewConfirm().
+		Title(fmt.Sprintf("Apagar o repositório %s criado no GitHub?", fullName)).
+		Description("O projeto local é mantido; o repositório pode ser criado de novo depois").
+		Affirmative("Sim").
+		Negative("Não").
+		Value(&remove)
+	if err := huh.NewForm(huh.NewGroup(confirm)).Run(); err != nil || !remove {
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.Muted).PaddingLeft(2).Render(
+			"Repositório mantido. Para apagar depois:",
+		))
+		fmt.Println(hint.Render(deleteCmd))
+		fmt.Println()
+		return
+	}
+
+	spinner := ui.NewSpinner(ui.IconGitHub + "  Apagando repositório")
+	spinner.Start()
+
+	if err := runCommand("", nil, []string{"gh", "repo", "delete", fullName, "--yes"}); err != nil {
+		spinner.Error(fmt.Sprintf("Não foi possível apagar: %v", err))
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.Muted).PaddingLeft(2).Render(
+			"Apagar repositórios exige o escopo delete_repo:",
+		))
+		fmt.Println(hint.Render("gh auth refresh -s delete_repo"))
+		fmt.Println(hint.Render(deleteCmd))
+		fmt.Println()
+		return
+	}
+
+	// O remote aponta para um repositório que não existe mais
+	runGit(projectDir, "remote", "remove", "origin")
+	spinner.Success(fmt.Sprintf("Repositório %s apagado", fullName))
+}
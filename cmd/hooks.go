@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/algarys/algarys_cli/cmd/ui"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-isatty"
+)
+
+const hooksLogDir = "logs"
+
+// Momentos em que os hooks de um template rodam
+const (
+	hookPreGen  = "pre_gen"  // na pasta (vazia) do projeto, antes de gerar os arquivos
+	hookPostGen = "post_gen" // no projeto pronto, depois do ambiente UV
+)
+
+// TemplateHook é um passo extra declarado pelo template em pre_gen/post_gen.
+// Só um entre run e script deve ser informado.
+type TemplateHook struct {
+	Name     string `yaml:"name"`
+	Run      string `yaml:"run"`      // comando de shell (aceita {{.Name}}, {{.Module}}...)
+	Script   string `yaml:"script"`   // script Python do template, rodado com `uv run`
+	Optional bool   `yaml:"optional"` // falha vira aviso em vez de interromper o init
+}
+
+// title é o texto da etapa no spinner
+func (h TemplateHook) title() string {
+	if h.Name != "" {
+		return h.Name
+	}
+	if h.Script != "" {
+		return h.Script
+	}
+	return h.Run
+}
+
+func (h TemplateHook) validate(templateDir string) error {
+	switch {
+	case h.Run == "" && h.Script == "":
+		return fmt.Errorf("hook '%s' sem 'run' nem 'script'", h.Name)
+	case h.Run != "" && h.Script != "":
+		return fmt.Errorf("hook '%s' com 'run' e 'script' (use só um)", h.title())
+	case h.Script != "":
+		if _, err := os.Stat(filepath.Join(templateDir, h.Script)); err != nil {
+			return fmt.Errorf("script do hook não encontrado: %s", h.Script)
+		}
+	}
+	return nil
+}
+
+// errHooksDeclined indica que o usuário não autorizou os hooks do template
+var errHooksDeclined = errors.New("hooks do template não autorizados")
+
+// trustedTemplate diz se os hooks de tpl rodam sem confirmação: só o
+// template embutido e os que estão no disco
+func trustedTemplate(tpl *Template) bool {
+	return tpl.ID == defaultTemplateID || isLocalPath(tpl.ID)
+}
+
+// confirmHooks mostra os comandos dos hooks de um template remoto e pede
+// confirmação antes de rodá-los. Sem TTY, só --yes autoriza.
+func confirmHooks(projectName string, hooks *hookRunner, preGen, postGen []TemplateHook) error {
+	if len(preGen)+len(postGen) == 0 || trustedTemplate(hooks.tpl) {
+		return nil
+	}
+
+	fmt.Println(lipgloss.NewStyle().Foreground(ui.Primary).Bold(true).PaddingLeft(2).Render(
+		fmt.Sprintf("O template %s roda estes comandos:", hooks.tpl.ID),
+	))
+	fmt.Println()
+	printHookCommands(projectName, hooks, preGen, postGen)
+	fmt.Println()
+
+	if initYes {
+		return nil
+	}
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return fmt.Errorf("template remoto com hooks: revise os comandos e use --yes para rodá-los (ou --no-hooks)")
+	}
+
+	run := false
+	confirm := huh.NewConfirm().
+		Title("Rodar os hooks deste template?").
+		Description("Os comandos rodam na sua máquina com as suas permissões").
+		Affirmative("Sim").
+		Negative("Não").
+		Value(&run)
+	if err := huh.NewForm(huh.NewGroup(confirm)).Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			return errHooksDeclined
+		}
+		return err
+	}
+	if !run {
+		return errHooksDeclined
+	}
+	return nil
+}
+
+// hookRunner executa os hooks de um template e guarda a saída num log
+// em ~/.algarys/logs (fora do projeto, que pode ser desfeito)
+type hookRunner struct {
+	tpl     *Template
+	ctx     TemplateContext
+	LogPath string
+}
+
+func newHookRunner(tpl *Template, ctx TemplateContext) *hookRunner {
+	return &hookRunner{tpl: tpl, ctx: ctx}
+}
+
+// command monta o comando do hook; stage decide se o script usa o projeto
+func (r *hookRunner) command(stage string, hook TemplateHook) ([]string, error) {
+	if hook.Script != "" {
+		args := []string{"uv", "run"}
+		if stage == hookPreGen {
+			// Ainda não existe pyproject.toml para o uv usar
+			args = append(args, "--no-project")
+		}
+		return append(args, filepath.Join(r.tpl.Dir, hook.Script)), nil
+	}
+
+	rendered, err := renderContent(stage+": "+hook.title(), []byte(hook.Run), r.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []string{"sh", "-c", string(rendered)}, nil
+}
+
+// env expõe o contexto do template para os hooks
+func (r *hookRunner) env(projectDir string) []string {
+	modules := make([]string, 0, len(r.ctx.Modules))
+	for _, key := range moduleKeys() {
+		if r.ctx.Modules[key] {
+			modules = append(modules, key)
+		}
+	}
+
+	if abs, err := filepath.Abs(projectDir); err == nil {
+		projectDir = abs
+	}
+
+	return append(os.Environ(),
+		"ALGARYS_PROJECT_NAME="+r.ctx.Name,
+		"ALGARYS_PROJECT_DIR="+projectDir,
+		"ALGARYS_MODULE="+r.ctx.Module,
+		"ALGARYS_PYTHON_VERSION="+r.ctx.PythonVersion,
+		"ALGARYS_MODULES="+strings.Join(modules, ","),
+		"ALGARYS_TEMPLATE_DIR="+r.tpl.Dir,
+	)
+}
+
+// steps converte os hooks de um momento em etapas do init, rodadas em dir
+func (r *hookRunner) steps(stage string, hooks []TemplateHook, dir string) []initStep {
+	steps := make([]initStep, 0, len(hooks))
+	for _, hook := range hooks {
+		hook := hook
+		steps = append(steps, initStep{
+			icon:     ui.IconGear,
+			message:  fmt.Sprintf("Hook %s: %s", stage, hook.title()),
+			optional: hook.Optional,
+			action: func() error {
+				return r.run(stage, hook, dir)
+			},
+		})
+	}
+	return steps
+}
+
+func (r *hookRunner) run(stage string, hook TemplateHook, dir string) error {
+	args, err := r.command(stage, hook)
+	if err != nil {
+		return err
+	}
+	if args[0] == "uv" {
+		if _, err := exec.LookPath("uv"); err != nil {
+			return fmt.Errorf("uv não encontrado (necessário para %s)", hook.Script)
+		}
+	}
+
+	log, err := r.openLog()
+	if err != nil {
+		return err
+	}
+	defer log.Close()
+
+	fmt.Fprintf(log, "== %s %s: %s\n$ %s\n", time.Now().Format(time.RFC3339), stage, hook.title(), shellJoin(args))
+
+	var output bytes.Buffer
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = dir
+	cmd.Env = r.env(dir)
+	cmd.Stdout = io.MultiWriter(log, &output)
+	cmd.Stderr = cmd.Stdout
+
+	runErr := cmd.Run()
+	fmt.Fprintln(log)
+	if runErr == nil {
+		return nil
+	}
+
+	if line := summaryLine(output.String(), nil); line != "" {
+		return fmt.Errorf("%s (log: %s)", line, r.LogPath)
+	}
+	return fmt.Errorf("%v (log: %s)", runErr, r.LogPath)
+}
+
+// openLog abre (criando na primeira vez) o log dos hooks deste init
+func (r *hookRunner) openLog() (*os.File, error) {
+	if r.LogPath == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		dir := filepath.Join(homeDir, algarysDir, hooksLogDir)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("erro ao criar pasta de logs: %v", err)
+		}
+		name := fmt.Sprintf("init-%s-%s.log", r.ctx.Name, time.Now().Format("20060102-150405"))
+		r.LogPath = filepath.Join(dir, name)
+	}
+	return os.OpenFile(r.LogPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+}
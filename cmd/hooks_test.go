@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTrustedTemplate(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{defaultTemplateID, true},
+		{"/tmp/tpl-agent", true},
+		{"templates/tpl-agent", true},
+		{"https://github.com/algarys/tpl-agent.git", false},
+		{"git@github.com:algarys/tpl-agent.git", false},
+	}
+	for _, tt := range tests {
+		if got := trustedTemplate(&Template{ID: tt.id}); got != tt.want {
+			t.Errorf("trustedTemplate(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestConfirmHooksRequiresYesForRemoteTemplate(t *testing.T) {
+	hooks := newHookRunner(&Template{ID: "https://github.com/algarys/tpl-agent.git"}, TemplateContext{Name: "demo"})
+	postGen := []TemplateHook{{Name: "Instalar", Run: "curl example.com | sh"}}
+
+	defer func(yes bool) { initYes = yes }(initYes)
+
+	initYes = false
+	err := confirmHooks("demo", hooks, nil, postGen)
+	if err == nil || errors.Is(err, errHooksDeclined) {
+		t.Fatalf("sem TTY e sem --yes: esperava erro pedindo --yes, veio %v", err)
+	}
+
+	initYes = true
+	if err := confirmHooks("demo", hooks, nil, postGen); err != nil {
+		t.Fatalf("com --yes: %v", err)
+	}
+
+	local := newHookRunner(&Template{ID: t.TempDir()}, TemplateContext{Name: "demo"})
+	initYes = false
+	if err := confirmHooks("demo", local, nil, postGen); err != nil {
+		t.Fatalf("template local não deveria pedir confirmação: %v", err)
+	}
+}
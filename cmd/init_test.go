@@ -0,0 +1,25 @@
+package cmd
+
+import "testing"
+
+func TestValidateProjectConfigUsesFormNameRules(t *testing.T) {
+	tpl, err := resolveTemplate("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"", "meu projeto", "class", "json", "fastapi", "-x"} {
+		config := ProjectConfig{Name: name}
+		if err := validateProjectConfig(&config, tpl); err == nil {
+			t.Errorf("validateProjectConfig(%q) aceitou um nome que o formulário rejeita", name)
+		}
+	}
+
+	config := ProjectConfig{Name: "meu-projeto"}
+	if err := validateProjectConfig(&config, tpl); err != nil {
+		t.Errorf("validateProjectConfig(meu-projeto) = %v", err)
+	}
+	if config.PythonVersion != pythonVersions[0].value {
+		t.Errorf("PythonVersion = %q, esperado o padrão %s", config.PythonVersion, pythonVersions[0].value)
+	}
+}
@@ -61,10 +61,10 @@ func runLogin(cmd *cobra.Command, args []string) {
 		// Verificar acesso à org
 		if hasOrgAccess() {
 			fmt.Println(lipgloss.NewStyle().Foreground(ui.Primary).PaddingLeft(2).Render(
-				fmt.Sprintf("%s Acesso à org algarys confirmado", ui.IconCheck),
+				fmt.Sprintf("%s Acesso à org %s confirmado", ui.IconCheck, cliConfig.Org),
 			))
 		} else {
-			fmt.Println(ui.RenderWarning("Sem acesso à org algarys. Peça convite ao admin."))
+			fmt.Println(ui.RenderWarning(fmt.Sprintf("Sem acesso à org %s. Peça convite ao admin.", cliConfig.Org)))
 		}
 		fmt.Println()
 		return
@@ -113,7 +113,7 @@ func runLogin(cmd *cobra.Command, args []string) {
 		if hasOrgAccess() {
 			fmt.Println()
 			fmt.Println(lipgloss.NewStyle().Foreground(ui.Primary).PaddingLeft(2).Render(
-				fmt.Sprintf("%s Acesso à org algarys confirmado", ui.IconCheck),
+				fmt.Sprintf("%s Acesso à org %s confirmado", ui.IconCheck, cliConfig.Org),
 			))
 		}
 	} else {
@@ -168,7 +168,7 @@ func getGitHubUser() string {
 }
 
 func hasOrgAccess() bool {
-	cmd := exec.Command("gh", "api", "orgs/algarys/members", "-q", "length")
+	cmd := exec.Command("gh", "api", fmt.Sprintf("orgs/%s/members", cliConfig.Org), "-q", "length")
 	cmd.Stdout = nil
 	cmd.Stderr = nil
 	return cmd.Run() == nil
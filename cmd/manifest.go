@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Manifesto gravado na raiz de cada projeto gerado pelo `algarys init`
+const projectManifestFile = ".algarys.toml"
+
+// ProjectManifest registra como o projeto foi gerado
+type ProjectManifest struct {
+	CLIVersion  string           `toml:"cli_version"`
+	GeneratedAt time.Time        `toml:"generated_at"`
+	Module      string           `toml:"module"`
+	Project     ProjectConfig    `toml:"project"`
+	Template    ManifestTemplate `toml:"template"`
+}
+
+// ManifestTemplate identifica o template (e a revisão) usado na geração
+type ManifestTemplate struct {
+	ID     string `toml:"id"`
+	Ref    string `toml:"ref,omitempty"`
+	Commit string `toml:"commit,omitempty"`
+	Repo   string `toml:"repo,omitempty"`   // raiz do repositório de um template local
+	Subdir string `toml:"subdir,omitempty"` // pasta do template dentro de repo
+}
+
+func newProjectManifest(config ProjectConfig, moduleName string, tpl *Template) ProjectManifest {
+	return ProjectManifest{
+		CLIVersion:  Version,
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+		Module:      moduleName,
+		Project:     config,
+		Template: ManifestTemplate{
+			ID:     tpl.ID,
+			Ref:    tpl.Ref,
+			Commit: tpl.Commit,
+			Repo:   tpl.Repo,
+			Subdir: tpl.Subdir,
+		},
+	}
+}
+
+// encodeProjectManifest serializa o manifesto em TOML
+func encodeProjectManifest(m ProjectManifest) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("# Gerado pelo Algarys CLI - usado por comandos como 'algarys add'\n\n")
+	if err := toml.NewEncoder(&buf).Encode(m); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeProjectManifest grava o .algarys.toml na raiz do projeto
+func writeProjectManifest(root string, m ProjectManifest) error {
+	data, err := encodeProjectManifest(m)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(root, projectManifestFile), data, 0644)
+}
+
+// readProjectManifest lê o .algarys.toml de root
+func readProjectManifest(root string) (*ProjectManifest, error) {
+	var m ProjectManifest
+	if _, err := toml.DecodeFile(filepath.Join(root, projectManifestFile), &m); err != nil {
+		return nil, fmt.Errorf("%s inválido: %v", projectManifestFile, err)
+	}
+	return &m, nil
+}
+
+// findProjectManifest sobe a partir de start até achar um .algarys.toml.
+// Retorna a raiz do projeto e o manifesto.
+func findProjectManifest(start string) (string, *ProjectManifest, error) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, projectManifestFile)); err == nil {
+			m, err := readProjectManifest(dir)
+			if err != nil {
+				return "", nil, err
+			}
+			return dir, m, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil, errNotAlgarysProject
+		}
+		dir = parent
+	}
+}
+
+var errNotAlgarysProject = fmt.Errorf("nenhum projeto Algarys encontrado (%s) a partir do diretório atual", projectManifestFile)
+
+// loadCurrentProject localiza o projeto Algarys a partir do diretório atual
+func loadCurrentProject() (string, *ProjectManifest, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", nil, err
+	}
+	return findProjectManifest(wd)
+}
@@ -0,0 +1,410 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/algarys/algarys_cli/cmd/ui"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type preflightLevel int
+
+const (
+	preflightOK preflightLevel = iota
+	preflightWarn
+	preflightFail
+)
+
+// preflightResult é o resultado de uma verificação do pre-flight
+type preflightResult struct {
+	Level   preflightLevel
+	Check   string
+	Message string
+	Hint    string // como resolver, quando há problema
+}
+
+type preflightReport []preflightResult
+
+func (r preflightReport) failures() int {
+	n := 0
+	for _, res := range r {
+		if res.Level == preflightFail {
+			n++
+		}
+	}
+	return n
+}
+
+// runPreflight verifica tudo o que o init precisa antes de escrever qualquer
+// coisa. Todas as verificações rodam, para mostrar os problemas de uma vez.
+func runPreflight(rawName string, config ProjectConfig, moduleName string) preflightReport {
+	var report preflightReport
+	report = append(report, checkProjectName(rawName, config.Name, moduleName))
+	report = append(report, checkTargetDir(config.Name))
+	report = append(report, checkGit(config.CreateGitHub)...)
+	report = append(report, checkUV())
+	if config.CreateGitHub {
+		report = append(report, checkGitHub(config.GitHubOrg, cliConfig.repoName(config.GitHubOrg, config.Name))...)
+	}
+	return report
+}
+
+// runPreflightWithSpinner roda o pre-flight e mostra o resumo
+func runPreflightWithSpinner(rawName string, config ProjectConfig, moduleName string) preflightReport {
+	spinner := ui.NewSpinner(ui.IconGear + "  Verificando pré-requisitos")
+	spinner.Start()
+	time.Sleep(300 * time.Millisecond)
+	report := runPreflight(rawName, config, moduleName)
+	spinner.Stop()
+
+	printPreflightReport(report)
+	return report
+}
+
+func printPreflightReport(report preflightReport) {
+	titleStyle := lipgloss.NewStyle().Foreground(ui.Primary).Bold(true).PaddingLeft(2)
+	checkStyle := lipgloss.NewStyle().Foreground(ui.TextDim).Width(12)
+	hintStyle := lipgloss.NewStyle().Foreground(ui.Muted).PaddingLeft(19)
+
+	fmt.Println(titleStyle.Render("Pré-requisitos:"))
+	fmt.Println()
+	for _, res := range report {
+		var icon string
+		switch res.Level {
+		case preflightOK:
+			icon = ui.SuccessStyle.Render(ui.IconSuccess)
+		case preflightWarn:
+			icon = ui.WarningStyle.Render(ui.IconWarning)
+		case preflightFail:
+			icon = ui.ErrorStyle.Render(ui.IconError)
+		}
+		fmt.Printf("    %s %s %s\n", icon, checkStyle.Render(res.Check), res.Message)
+		if res.Hint != "" && res.Level != preflightOK {
+			fmt.Println(hintStyle.Render(res.Hint))
+		}
+	}
+	fmt.Println()
+}
+
+// normalizeProjectName aplica a normalização da PEP 503: minúsculas e
+// sequências de -, _ e . viram um único hífen
+func normalizeProjectName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.ReplaceAll(name, " ", "-")
+	return pep503Separators.ReplaceAllString(name, "-")
+}
+
+// projectModuleName é o pacote Python derivado do nome normalizado
+func projectModuleName(normalized string) string {
+	return strings.ReplaceAll(normalized, "-", "_")
+}
+
+var (
+	pep503Separators = regexp.MustCompile(`[-_.]+`)
+	// Nome de distribuição válido segundo a PEP 508
+	pep508Name = regexp.MustCompile(`^(?i)([A-Z0-9]|[A-Z0-9][A-Z0-9._-]*[A-Z0-9])$`)
+)
+
+// validateProjectName valida o nome do projeto e o pacote Python derivado dele
+func validateProjectName(s string) error {
+	if s == "" {
+		return fmt.Errorf("nome é obrigatório")
+	}
+	if strings.Contains(s, " ") {
+		return fmt.Errorf("use hífen ao invés de espaços")
+	}
+	if !pep508Name.MatchString(s) {
+		return fmt.Errorf("nome inválido: use letras, números e hífens, começando e terminando com letra ou número")
+	}
+
+	module := projectModuleName(normalizeProjectName(s))
+	switch {
+	case !pythonIdentifier.MatchString(module):
+		return fmt.Errorf("o pacote '%s' não é um identificador Python válido (não pode começar com número)", module)
+	case pythonKeywords[module]:
+		return fmt.Errorf("o pacote '%s' é uma palavra reservada do Python", module)
+	case pythonStdlibModules[module]:
+		return fmt.Errorf("o pacote '%s' esconderia o módulo '%s' da biblioteca padrão", module, module)
+	case shadowedDependencies[module]:
+		return fmt.Errorf("o pacote '%s' esconderia a dependência '%s' do projeto", module, module)
+	}
+	return nil
+}
+
+func checkProjectName(rawName, name, moduleName string) preflightResult {
+	if err := validateProjectName(rawName); err != nil {
+		return preflightResult{Level: preflightFail, Check: "nome", Message: err.Error(),
+			Hint: "Escolha outro nome (ex: meu-projeto)"}
+	}
+
+	msg := fmt.Sprintf("%s (pacote %s)", name, moduleName)
+	if rawName != name {
+		msg += fmt.Sprintf(", normalizado de '%s'", rawName)
+	}
+	return preflightResult{Level: preflightOK, Check: "nome", Message: msg}
+}
+
+func checkTargetDir(name string) preflightResult {
+	if _, err := os.Lstat(name); !os.IsNotExist(err) {
+		return preflightResult{Level: preflightFail, Check: "diretório", Message: fmt.Sprintf("'%s' já existe", name),
+			Hint: "Use outro nome ou remova o diretório"}
+	}
+
+	// O projeto é montado numa pasta temporária ao lado do destino
+	parent, err := filepath.Abs(filepath.Dir(name))
+	if err == nil {
+		var probe string
+		probe, err = os.MkdirTemp(parent, ".algarys-preflight-*")
+		if err == nil {
+			os.Remove(probe)
+		}
+	}
+	if err != nil {
+		return preflightResult{Level: preflightFail, Check: "diretório", Message: fmt.Sprintf("sem permissão de escrita em %s", parent)}
+	}
+	return preflightResult{Level: preflightOK, Check: "diretório", Message: filepath.Join(parent, name)}
+}
+
+func checkGit(required bool) []preflightResult {
+	if _, err := exec.LookPath("git"); err != nil {
+		level := preflightWarn
+		msg := "não encontrado: o repositório local não será criado"
+		if required {
+			level = preflightFail
+			msg = "não encontrado (necessário para enviar ao GitHub)"
+		}
+		return []preflightResult{{Level: level, Check: "git", Message: msg, Hint: "Instale: https://git-scm.com/downloads"}}
+	}
+
+	// Sem identidade o commit inicial falharia
+	if _, err := runGit("", "var", "GIT_AUTHOR_IDENT"); err != nil {
+		return []preflightResult{{Level: preflightFail, Check: "git", Message: "identidade não configurada (user.name/user.email)",
+			Hint: `git config --global user.name "Seu Nome" && git config --global user.email voce@algarys.com`}}
+	}
+	return []preflightResult{{Level: preflightOK, Check: "git", Message: "instalado e configurado"}}
+}
+
+func checkUV() preflightResult {
+	if _, err := exec.LookPath("uv"); err != nil {
+		return preflightResult{Level: preflightWarn, Check: "uv", Message: "não encontrado: o ambiente não será instalado",
+			Hint: "Instale: curl -LsSf https://astral.sh/uv/install.sh | sh"}
+	}
+	return preflightResult{Level: preflightOK, Check: "uv", Message: "instalado"}
+}
+
+// checkGitHub verifica gh, autenticação, permissão na org e se o nome do repo está livre
+func checkGitHub(org, repoName string) []preflightResult {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return []preflightResult{{Level: preflightFail, Check: "gh", Message: "GitHub CLI não encontrado",
+			Hint: "Instale: brew install gh (ou https://cli.github.com)"}}
+	}
+	if !IsLoggedIn() {
+		return []preflightResult{{Level: preflightFail, Check: "gh", Message: "não autenticado", Hint: "Execute: algarys login"}}
+	}
+
+	user := getGitHubUser()
+	results := []preflightResult{{Level: preflightOK, Check: "gh", Message: "autenticado como " + user}}
+	results = append(results, checkOrgPermission(org, user))
+	results = append(results, checkRepoAvailable(org, repoName))
+	return results
+}
+
+func checkOrgPermission(org, user string) preflightResult {
+	if strings.EqualFold(org, user) {
+		return preflightResult{Level: preflightOK, Check: "org", Message: "repositório na conta pessoal"}
+	}
+
+	membership, err := ghAPI(fmt.Sprintf("user/memberships/orgs/%s", org), `.state + " " + .role`)
+	if err != nil {
+		if isNotFound(err) {
+			return preflightResult{Level: preflightFail, Check: "org", Message: fmt.Sprintf("você não é membro de %s", org),
+				Hint: "Peça convite ao admin da org"}
+		}
+		return preflightResult{Level: preflightWarn, Check: "org", Message: fmt.Sprintf("não foi possível verificar %s: %v", org, err)}
+	}
+
+	state, role, _ := strings.Cut(membership, " ")
+	switch {
+	case state != "active":
+		return preflightResult{Level: preflightFail, Check: "org", Message: fmt.Sprintf("convite para %s pendente", org),
+			Hint: fmt.Sprintf("Aceite em https://github.com/orgs/%s/invitation", org)}
+	case role == "admin":
+		return preflightResult{Level: preflightOK, Check: "org", Message: fmt.Sprintf("admin de %s", org)}
+	}
+
+	canCreate, err := ghAPI("orgs/"+org, fmt.Sprintf(".members_can_create_%s_repositories", cliConfig.Visibility))
+	if err != nil || canCreate == "" || canCreate == "null" {
+		return preflightResult{Level: preflightWarn, Check: "org", Message: fmt.Sprintf("membro de %s (permissão de criar repos não verificada)", org)}
+	}
+	if canCreate != "true" {
+		return preflightResult{Level: preflightFail, Check: "org", Message: fmt.Sprintf("membros de %s não podem criar repositórios %s", org, visibilityLabels[cliConfig.Visibility]),
+			Hint: "Peça ao admin para criar o repositório ou liberar a permissão"}
+	}
+	return preflightResult{Level: preflightOK, Check: "org", Message: fmt.Sprintf("membro de %s com permissão para criar repos", org)}
+}
+
+func checkRepoAvailable(org, repoName string) preflightResult {
+	full := fmt.Sprintf("%s/%s", org, repoName)
+	_, err := ghAPI("repos/"+full, ".full_name")
+	switch {
+	case err == nil:
+		return preflightResult{Level: preflightFail, Check: "repositório", Message: fmt.Sprintf("%s já existe", full),
+			Hint: "Use outro nome de projeto"}
+	case isNotFound(err):
+		return preflightResult{Level: preflightOK, Check: "repositório", Message: fmt.Sprintf("%s disponível", full)}
+	}
+	return preflightResult{Level: preflightWarn, Check: "repositório", Message: fmt.Sprintf("não foi possível verificar %s: %v", full, err)}
+}
+
+// ghAPI faz um GET na API do GitHub via gh e devolve o resultado do filtro jq.
+// flags extras vão direto para o gh api (ex: --paginate).
+func ghAPI(path, jq string, flags ...string) (string, error) {
+	cmd := exec.Command("gh", append([]string{"api", path, "-q", jq}, flags...)...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// githubAPI são as leituras da API REST do GitHub: o JSON da resposta de
+// path (ex: repos/org/repo) é decodificado em v. Erros de status trazem
+// "HTTP <código>", como os do gh.
+type githubAPI interface {
+	get(path string, v any) error
+}
+
+// ghCLI lê a API pelo gh, com a autenticação do usuário
+type ghCLI struct{}
+
+func (ghCLI) get(path string, v any) error {
+	out, err := ghAPI(path, ".")
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(out), v); err != nil {
+		return fmt.Errorf("resposta inválida da API: %v", err)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "HTTP 404")
+}
+
+// Palavras reservadas do Python, comparadas com o nome exato: True, False e
+// None não colidem com pacotes (sempre em minúsculas), mas sim com as
+// classes geradas pelo `algarys add`
+var pythonKeywords = setOf(
+	"False", "None", "True", "and", "as", "assert", "async", "await", "break",
+	"class", "continue", "def", "del", "elif", "else", "except", "finally", "for",
+	"from", "global", "if", "import", "in", "is", "lambda", "nonlocal", "not",
+	"or", "pass", "raise", "return", "try", "while", "with", "yield",
+)
+
+// Módulos de topo da biblioteca padrão (sys.stdlib_module_names, sem os privados)
+var pythonStdlibModules = setOf(
+	"abc", "aifc", "antigravity", "argparse", "array", "ast", "asynchat",
+	"asyncio", "asyncore", "atexit", "audioop", "base64", "bdb", "binascii",
+	"bisect", "builtins", "bz2", "cProfile", "calendar", "cgi", "cgitb",
+	"chunk", "cmath", "cmd", "code", "codecs", "codeop", "collections",
+	"colorsys", "compileall", "concurrent", "configparser", "contextlib",
+	"contextvars", "copy", "copyreg", "crypt", "csv", "ctypes", "curses",
+	"dataclasses", "datetime", "dbm", "decimal", "difflib", "dis", "distutils",
+	"doctest", "email", "encodings", "ensurepip", "enum", "errno",
+	"faulthandler", "fcntl", "filecmp", "fileinput", "fnmatch", "fractions",
+	"ftplib", "functools", "gc", "genericpath", "getopt", "getpass", "gettext",
+	"glob", "graphlib", "grp", "gzip", "hashlib", "heapq", "hmac", "html",
+	"http", "idlelib", "imaplib", "imghdr", "imp", "importlib", "inspect",
+	"io", "ipaddress", "itertools", "json", "keyword", "lib2to3", "linecache",
+	"locale", "logging", "lzma", "mailbox", "mailcap", "marshal", "math",
+	"mimetypes", "mmap", "modulefinder", "msilib", "msvcrt", "multiprocessing",
+	"netrc", "nis", "nntplib", "nt", "ntpath", "nturl2path", "numbers",
+	"opcode", "operator", "optparse", "os", "ossaudiodev", "pathlib", "pdb",
+	"pickle", "pickletools", "pipes", "pkgutil", "platform", "plistlib",
+	"poplib", "posix", "posixpath", "pprint", "profile", "pstats", "pty",
+	"pwd", "py_compile", "pyclbr", "pydoc", "pydoc_data", "pyexpat", "queue",
+	"quopri", "random", "re", "readline", "reprlib", "resource", "rlcompleter",
+	"runpy", "sched", "secrets", "select", "selectors", "shelve", "shlex",
+	"shutil", "signal", "site", "smtpd", "smtplib", "sndhdr", "socket",
+	"socketserver", "spwd", "sqlite3", "sre_compile", "sre_constants",
+	"sre_parse", "ssl", "stat", "statistics", "string", "stringprep", "struct",
+	"subprocess", "sunau", "symtable", "sys", "sysconfig", "syslog",
+	"tabnanny", "tarfile", "telnetlib", "tempfile", "termios", "textwrap",
+	"this", "threading", "time", "timeit", "tkinter", "token", "tokenize",
+	"tomllib", "trace", "traceback", "tracemalloc", "tty", "turtle",
+	"turtledemo", "types", "typing", "unicodedata", "unittest", "urllib", "uu",
+	"uuid", "venv", "warnings", "wave", "weakref", "webbrowser", "winreg",
+	"winsound", "wsgiref", "xdrlib", "xml", "xmlrpc", "zipapp", "zipfile",
+	"zipimport", "zlib", "zoneinfo",
+)
+
+// Pacotes das dependências do template padrão (com todos os módulos) que
+// um pacote do projeto com o mesmo nome esconderia
+var shadowedDependencies = templateDependencyModules()
+
+var dependencyNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*`)
+
+// templateDependencyModules lê as dependências do pyproject.toml do template
+// padrão e devolve o nome de import de cada uma (uvicorn[standard]>=0.27
+// vira uvicorn, pydantic-settings vira pydantic_settings)
+func templateDependencyModules() map[string]bool {
+	const name = "algarys-preflight"
+	config := ProjectConfig{Name: name, PythonVersion: pythonVersions[0].value, Modules: moduleKeys()}
+
+	// Template embutido em tempo de compilação: um erro aqui é bug do CLI
+	tmpl, err := fs.ReadFile(defaultTemplate(), "pyproject.toml"+templateExt)
+	if err != nil {
+		panic(err)
+	}
+	content, err := renderContent("pyproject.toml", tmpl, newTemplateContext(config, projectModuleName(name)))
+	if err != nil {
+		panic(err)
+	}
+	var pyproject struct {
+		Project struct {
+			Dependencies         []string            `toml:"dependencies"`
+			OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+		} `toml:"project"`
+		BuildSystem struct {
+			Requires []string `toml:"requires"`
+		} `toml:"build-system"`
+	}
+	if _, err := toml.Decode(string(content), &pyproject); err != nil {
+		panic(err)
+	}
+
+	deps := append(pyproject.Project.Dependencies, pyproject.BuildSystem.Requires...)
+	for _, extra := range pyproject.Project.OptionalDependencies {
+		deps = append(deps, extra...)
+	}
+
+	modules := map[string]bool{}
+	for _, dep := range deps {
+		dist := normalizeProjectName(dependencyNameRe.FindString(dep))
+		if dist != "" && dist != name { // o extra "all" aponta para o próprio projeto
+			modules[projectModuleName(dist)] = true
+		}
+	}
+	return modules
+}
+
+func setOf(items ...string) map[string]bool {
+	m := make(map[string]bool, len(items))
+	for _, item := range items {
+		m[item] = true
+	}
+	return m
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import "testing"
+
+func TestValidateProjectName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"meu-projeto", true},
+		{"Billing_API", true},
+		{"class", false},
+		{"json", false},
+		{"1projeto", false},
+		// Dependências do template
+		{"fastapi", false},
+		{"typer", false},
+		{"httpx", false},
+		{"uvicorn", false},
+		{"langchain", false},
+		{"pydantic-settings", false},
+		{"temporalio", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateProjectName(tt.name)
+			if (err == nil) != tt.valid {
+				t.Errorf("validateProjectName(%q) = %v, válido esperado: %v", tt.name, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestShadowedDependenciesSkipsProjectItself(t *testing.T) {
+	if shadowedDependencies["algarys_preflight"] {
+		t.Error("o extra 'all' do próprio projeto entrou na lista de dependências")
+	}
+	if !shadowedDependencies["pydantic_settings"] || !shadowedDependencies["hatchling"] {
+		t.Errorf("dependências do template faltando: %v", shadowedDependencies)
+	}
+}
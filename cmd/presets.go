@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Módulos opcionais do template padrão. Cada módulo controla suas pastas,
+// extras do pyproject.toml, seções do README e chaves do .env.example.
+var projectModules = []struct {
+	key   string
+	label string
+}{
+	{"domain", "Domain (entidades, repositórios, value objects)"},
+	{"application", "Application (casos de uso, serviços, DTOs)"},
+	{"infrastructure", "Infrastructure (banco, integrações externas)"},
+	{"interfaces", "Interfaces (API, CLI)"},
+	{"api", "API HTTP (FastAPI em interfaces/api)"},
+	{"cli", "CLI (Typer em interfaces/cli)"},
+	{"ai", "AI (agentes, tools, prompts)"},
+	{"temporal", "Temporal (activities, workflows, worker)"},
+	{"docker", "Docker (Dockerfile + docker-compose com Temporal e Postgres)"},
+}
+
+// Módulos que dependem de outros: escolher a chave inclui os módulos da lista
+var moduleRequires = map[string][]string{
+	"api": {"application", "interfaces"},
+	"cli": {"application", "interfaces"},
+	"ai":  {"infrastructure"}, // provedores de LLM ficam em infrastructure/external
+}
+
+// moduleOption é uma opção de uma escolha de módulo
+type moduleOption struct {
+	label string
+	value string
+}
+
+// Provedores de LLM do módulo ai (o primeiro é o padrão). Os dois adapters
+// são sempre gerados; a escolha define o LLM_PROVIDER padrão.
+var llmProviders = []moduleOption{
+	{"OpenAI", "openai"},
+	{"Anthropic", "anthropic"},
+}
+
+// Autenticação do worker do módulo temporal (a primeira é o padrão)
+var temporalAuthModes = []moduleOption{
+	{"Servidor local, sem TLS (temporal server start-dev)", "local"},
+	{"Temporal Cloud com mTLS (certificado e chave)", "mtls"},
+	{"Temporal Cloud com API key", "api-key"},
+}
+
+// moduleChoice é uma pergunta extra feita só quando o módulo está habilitado
+type moduleChoice struct {
+	module      string
+	name        string // usado nos erros
+	title       string
+	description string
+	options     []moduleOption
+	field       func(config *ProjectConfig) *string
+}
+
+var moduleChoices = []moduleChoice{
+	{
+		module:      "ai",
+		name:        "provedor de LLM",
+		title:       "🤖 Provedor de LLM",
+		description: "Padrão do LLM_PROVIDER; os adapters de OpenAI e Anthropic são gerados",
+		options:     llmProviders,
+		field:       func(c *ProjectConfig) *string { return &c.LLMProvider },
+	},
+	{
+		module:      "temporal",
+		name:        "autenticação do Temporal",
+		title:       "⏱️  Conexão do worker com o Temporal",
+		description: "Define o TLS/API key gerados no worker e no .env.example",
+		options:     temporalAuthModes,
+		field:       func(c *ProjectConfig) *string { return &c.TemporalAuth },
+	},
+}
+
+const customPreset = "custom"
+const defaultPreset = "full"
+
+// Presets de projeto (o primeiro é o padrão)
+var projectPresets = []struct {
+	key     string
+	label   string
+	modules []string
+}{
+	{"full", "Completo (SOLID + AI + Temporal)", []string{"domain", "application", "infrastructure", "interfaces", "api", "cli", "ai", "temporal", "docker"}},
+	{"api", "API (SOLID + FastAPI, sem AI e Temporal)", []string{"domain", "application", "infrastructure", "interfaces", "api"}},
+	{"agent", "Agente de IA", []string{"domain", "application", "infrastructure", "ai"}},
+	{"temporal-worker", "Worker Temporal", []string{"domain", "application", "infrastructure", "temporal"}},
+	{"minimal", "Mínimo (domain + application)", []string{"domain", "application"}},
+}
+
+// presetModules retorna os módulos de um preset
+func presetModules(preset string) ([]string, error) {
+	for _, p := range projectPresets {
+		if p.key == preset {
+			return append([]string(nil), p.modules...), nil
+		}
+	}
+
+	keys := make([]string, 0, len(projectPresets))
+	for _, p := range projectPresets {
+		keys = append(keys, p.key)
+	}
+	return nil, fmt.Errorf("preset inválido: %s (use %s)", preset, strings.Join(keys, ", "))
+}
+
+// validateModules confere se todos os módulos existem
+func validateModules(modules []string) error {
+	for _, m := range modules {
+		if !isKnownModule(m) {
+			return fmt.Errorf("módulo inválido: %s (use %s)", m, strings.Join(moduleKeys(), ", "))
+		}
+	}
+	return nil
+}
+
+func moduleKeys() []string {
+	keys := make([]string, 0, len(projectModules))
+	for _, m := range projectModules {
+		keys = append(keys, m.key)
+	}
+	return keys
+}
+
+func isKnownModule(key string) bool {
+	for _, m := range projectModules {
+		if m.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveModules define preset e módulos finais: módulos explícitos viram
+// preset "custom"; sem nada informado, usa o preset padrão
+func resolveModules(config *ProjectConfig) error {
+	if config.Preset == "" && len(config.Modules) > 0 {
+		config.Preset = customPreset
+	}
+
+	if config.Preset == customPreset {
+		config.Modules = normalizeModules(config.Modules)
+		if err := validateModules(config.Modules); err != nil {
+			return err
+		}
+		config.Modules = withRequiredModules(config.Modules)
+		return nil
+	}
+
+	if config.Preset == "" {
+		config.Preset = defaultPreset
+	}
+	modules, err := presetModules(config.Preset)
+	if err != nil {
+		return err
+	}
+	config.Modules = modules
+	return nil
+}
+
+// withRequiredModules inclui os módulos exigidos pelos selecionados
+func withRequiredModules(modules []string) []string {
+	set := modulesSet(modules)
+	for _, m := range modules {
+		for _, req := range moduleRequires[m] {
+			if !set[req] {
+				set[req] = true
+				modules = append(modules, req)
+			}
+		}
+	}
+	return normalizeModules(modules)
+}
+
+// normalizeModules remove duplicados e ordena como em projectModules
+func normalizeModules(modules []string) []string {
+	order := map[string]int{}
+	for i, m := range projectModules {
+		order[m.key] = i
+	}
+
+	seen := map[string]bool{}
+	var result []string
+	for _, m := range modules {
+		m = strings.TrimSpace(strings.ToLower(m))
+		if m == "" || seen[m] {
+			continue
+		}
+		seen[m] = true
+		result = append(result, m)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		oi, iok := order[result[i]]
+		oj, jok := order[result[j]]
+		if iok && jok {
+			return oi < oj
+		}
+		return iok
+	})
+	return result
+}
+
+func modulesSet(modules []string) map[string]bool {
+	set := make(map[string]bool, len(modules))
+	for _, m := range modules {
+		set[m] = true
+	}
+	return set
+}
+
+// resolveModuleChoices aplica o padrão das escolhas dos módulos habilitados
+// (e limpa as dos módulos que ficaram de fora)
+func resolveModuleChoices(config *ProjectConfig) error {
+	enabled := modulesSet(config.Modules)
+	for _, choice := range moduleChoices {
+		value := choice.field(config)
+		if !enabled[choice.module] {
+			*value = ""
+			continue
+		}
+		if *value == "" {
+			*value = choice.options[0].value
+		}
+		if !hasModuleOption(choice.options, *value) {
+			values := make([]string, 0, len(choice.options))
+			for _, o := range choice.options {
+				values = append(values, o.value)
+			}
+			return fmt.Errorf("%s inválido: %s (use %s)", choice.name, *value, strings.Join(values, ", "))
+		}
+	}
+	return nil
+}
+
+func hasModuleOption(options []moduleOption, value string) bool {
+	for _, o := range options {
+		if o.value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// optionOrDefault devolve value ou, vazio (manifestos anteriores à
+// escolha), a primeira opção
+func optionOrDefault(value string, options []moduleOption) string {
+	if value == "" {
+		return options[0].value
+	}
+	return value
+}
@@ -0,0 +1,365 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/algarys/algarys_cli/cmd/ui"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+var (
+	repoOrg             string
+	repoPolicy          string
+	repoProtectDryRun   bool
+	repoCreateName      string
+	repoCreateDesc      string
+	repoCreateBootstrap string
+	repoCreateNoBoot    bool
+	repoCreateExisting  bool
+)
+
+var repoCmd = &cobra.Command{
+	Use:   "repo",
+	Short: "Cria e protege repositórios da org no GitHub",
+}
+
+var repoProtectCmd = &cobra.Command{
+	Use:   "protect <repo>",
+	Short: "Aplica o ruleset da política de proteção a um repositório",
+	Long: `Cria ou atualiza (pelo nome) o ruleset da política da org no repositório.
+
+A política vem embutida no CLI (cmd/policies/ruleset.yaml) ou de --policy.
+Rodar de novo depois de uma mudança na política atualiza o ruleset existente.
+Os status checks só são exigidos se o repositório tiver o workflow da política.
+
+Exemplos:
+  algarys repo protect algarys_meu-projeto
+  algarys repo protect outra-org/servico --policy politica.yaml --dry-run`,
+	Args: cobra.ExactArgs(1),
+	Run:  runRepoProtect,
+}
+
+var repoCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Cria o repositório no GitHub para o projeto atual",
+	Long: `Cria o repositório de um projeto que já existe localmente (ex: quem
+respondeu "Não" ao GitHub no init), envia o código e aplica o bootstrap e o
+ruleset, como o init faria. O nome segue o repo_pattern (algarys config),
+ex: algarys_<nome>.
+
+Rode de qualquer pasta do projeto. O nome e a descrição vêm do .algarys.toml
+(ou da pasta do repositório git, sem manifesto). Se o remote origin já
+apontar para o repositório, ele é reaproveitado. Se o repositório já existir
+no GitHub e o projeto não tiver remote, o origin só é adicionado (e o código
+enviado para lá) com confirmação ou --existing.
+
+Exemplos:
+  algarys repo create
+  algarys repo create --org outra-org --no-bootstrap`,
+	Args: cobra.NoArgs,
+	Run:  runRepoCreate,
+}
+
+func init() {
+	rootCmd.AddCommand(repoCmd)
+	repoCmd.AddCommand(repoProtectCmd)
+	repoCmd.AddCommand(repoCreateCmd)
+
+	repoCmd.PersistentFlags().StringVar(&repoOrg, "org", cliConfig.Org, "Organização do GitHub (quando o repo vem sem owner/)")
+	repoCmd.PersistentFlags().StringVar(&repoPolicy, "policy", "", "Arquivo YAML da política (padrão: a embutida no CLI)")
+	repoProtectCmd.Flags().BoolVar(&repoProtectDryRun, "dry-run", false, "Mostrar o ruleset que seria aplicado, sem alterar nada")
+
+	repoCreateCmd.Flags().StringVar(&repoCreateName, "name", "", "Nome do projeto (padrão: o do .algarys.toml)")
+	repoCreateCmd.Flags().StringVar(&repoCreateDesc, "description", "", "Descrição do repositório (padrão: a do .algarys.toml)")
+	repoCreateCmd.Flags().StringVar(&repoCreateBootstrap, "bootstrap", "", "Configuração do bootstrap do repositório (padrão: a embutida no CLI)")
+	repoCreateCmd.Flags().BoolVar(&repoCreateNoBoot, "no-bootstrap", false, "Só criar o repositório, enviar o código e aplicar o ruleset")
+	repoCreateCmd.Flags().BoolVar(&repoCreateExisting, "existing", false, "Usar o repositório que já existe no GitHub como origin, sem perguntar")
+}
+
+func runRepoProtect(cmd *cobra.Command, args []string) {
+	org, repoName := splitRepoArg(args[0], repoOrg)
+	fullName := org + "/" + repoName
+
+	policy, err := loadRulesetPolicy(repoPolicy)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	if err := requireGitHubCLI(); err != nil {
+		exitWithError(err.Error())
+	}
+
+	fmt.Println()
+	spinner := ui.NewSpinner(ui.IconGitHub + "  Lendo " + fullName)
+	spinner.Start()
+
+	if _, err := ghAPI("repos/"+fullName, ".full_name"); err != nil {
+		spinner.Error(fmt.Sprintf("Repositório %s não encontrado", fullName))
+		exitWithError(err.Error())
+	}
+	hasWorkflow := true
+	if policy.StatusChecks.Workflow != "" {
+		_, err := ghAPI(fmt.Sprintf("repos/%s/contents/%s", fullName, policy.StatusChecks.Workflow), ".path")
+		hasWorkflow = err == nil
+	}
+	requiredChecks := policy.requiredChecks(hasWorkflow)
+
+	existingID, err := findRuleset(ghCLI{}, repoName, org, policy.Name)
+	if err != nil {
+		spinner.Error("Não foi possível listar os rulesets")
+		exitWithError(err.Error())
+	}
+	spinner.Success(fmt.Sprintf("Repositório %s encontrado", fullName))
+
+	if !hasWorkflow && len(policy.StatusChecks.Checks) > 0 {
+		fmt.Println(ui.RenderWarning(fmt.Sprintf("Sem %s: status checks (%s) não serão exigidos",
+			policy.StatusChecks.Workflow, strings.Join(policy.StatusChecks.Checks, ", "))))
+	}
+
+	action := "criado"
+	if existingID != "" {
+		action = "atualizado"
+	}
+
+	payload, err := policy.rulesetJSON(org, requiredChecks)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+
+	if repoProtectDryRun {
+		fmt.Println(ui.RenderInfo(fmt.Sprintf("Dry-run: o ruleset '%s' seria %s (%s)", policy.Name, action, policy.describe(requiredChecks))))
+		fmt.Println()
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.Text).PaddingLeft(4).Render(shellJoin(rulesetAPICommand(repoName, org, existingID))))
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.TextDim).PaddingLeft(4).Render(payload))
+		fmt.Println()
+		return
+	}
+
+	spinner = ui.NewSpinner(ui.IconLock + "  Aplicando o ruleset '" + policy.Name + "'")
+	spinner.Start()
+	if err := writeRuleset(repoName, org, existingID, payload); err != nil {
+		spinner.Error("Ruleset não aplicado")
+		exitWithError(err.Error())
+	}
+	spinner.Success(fmt.Sprintf("Ruleset '%s' %s em %s", policy.Name, action, fullName))
+	fmt.Println(ui.RenderInfo(policy.describe(requiredChecks)))
+	fmt.Println()
+}
+
+func runRepoCreate(cmd *cobra.Command, args []string) {
+	root, name, description, org, err := locateLocalProject(cmd.Flags().Changed("org"))
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	repoName := cliConfig.repoName(org, name)
+	fullName := org + "/" + repoName
+
+	policy, err := loadRulesetPolicy(repoPolicy)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	var bootstrap *BootstrapConfig
+	if !repoCreateNoBoot {
+		if bootstrap, err = loadBootstrapConfig(repoCreateBootstrap); err != nil {
+			exitWithError(err.Error())
+		}
+	}
+	if err := requireGitHubCLI(); err != nil {
+		exitWithError(err.Error())
+	}
+
+	// Estado atual: git local, remote origin e repositório no GitHub
+	fmt.Println()
+	spinner := ui.NewSpinner(ui.IconGitHub + "  Verificando " + fullName)
+	spinner.Start()
+	_, gitErr := runGit(root, "rev-parse", "--git-dir")
+	hasGit := gitErr == nil
+	origin := ""
+	if hasGit {
+		origin, _ = runGit(root, "remote", "get-url", "origin")
+	}
+	_, repoErr := ghAPI("repos/"+fullName, ".full_name")
+	if repoErr != nil && !isNotFound(repoErr) {
+		spinner.Error(fmt.Sprintf("Não foi possível verificar %s", fullName))
+		exitWithError(repoErr.Error())
+	}
+	repoExists := repoErr == nil
+	spinner.Stop()
+
+	if origin == "" && repoExists && !repoCreateExisting && !confirmExistingRepo(fullName) {
+		exitWithError(fmt.Sprintf("%s já existe no GitHub e o projeto não tem remote: confira se é o mesmo projeto e rode de novo com --existing (ou use --name)", fullName))
+	}
+
+	if origin != "" {
+		owner, remoteRepo, ok := parseGitHubRemote(origin)
+		if !ok || !strings.EqualFold(owner+"/"+remoteRepo, fullName) {
+			exitWithError(fmt.Sprintf("o remote origin aponta para %s, não para %s (remova com: git remote remove origin)", origin, fullName))
+		}
+		if !repoExists {
+			exitWithError(fmt.Sprintf("o remote origin aponta para %s, que não existe no GitHub (remova com: git remote remove origin)", fullName))
+		}
+	}
+
+	var steps []initStep
+	if !hasGit {
+		steps = append(steps, initStep{icon: ui.IconGit, message: "Inicializando repositório Git", action: func() error {
+			return initLocalGit(root)
+		}})
+	} else if _, err := runGit(root, "rev-parse", "--verify", "-q", "main"); err != nil {
+		exitWithError("branch main não encontrada: o push e o ruleset usam a main (crie com: git branch -M main)")
+	}
+
+	created := false
+	switch {
+	case !repoExists:
+		steps = append(steps, initStep{icon: ui.IconGitHub, message: "Criando repositório github.com/" + fullName, action: func() error {
+			if err := runCommand(root, nil, ghRepoCreateCommand(name, description, org)); err != nil {
+				return err
+			}
+			created = true
+			return nil
+		}})
+	case origin == "":
+		steps = append(steps, initStep{icon: ui.IconGit, message: "Adicionando o remote origin", action: func() error {
+			url, err := githubRemoteURL(fullName)
+			if err != nil {
+				return err
+			}
+			return runCommand(root, nil, []string{"git", "remote", "add", "origin", url})
+		}})
+	default:
+		fmt.Println(ui.RenderInfo(fmt.Sprintf("Repositório %s já existe e é o origin: só envia o código e aplica as regras", fullName)))
+	}
+
+	_, workflowErr := os.Stat(filepath.Join(root, filepath.FromSlash(policy.StatusChecks.Workflow)))
+	requiredChecks := policy.requiredChecks(policy.StatusChecks.Workflow == "" || workflowErr == nil)
+	var updated bool
+	steps = append(steps, repoSetupSteps(root, org, repoName, bootstrap, func() error {
+		var err error
+		updated, err = applyRuleset(repoName, org, policy, requiredChecks)
+		return err
+	})...)
+
+	if err := runInitSteps(steps); err != nil {
+		fmt.Println(ui.RenderWarning(err.Error()))
+		if created {
+			offerGitHubCleanup(root, fullName)
+		}
+		os.Exit(1)
+	}
+
+	action := "configurado"
+	if updated {
+		action = "atualizado"
+	}
+	fmt.Println(ui.RenderInfo(fmt.Sprintf("Ruleset '%s' %s (%s)", policy.Name, action, policy.describe(requiredChecks))))
+	fmt.Println(ui.RenderSuccess("github.com/" + fullName))
+	fmt.Println()
+}
+
+// confirmExistingRepo pergunta se o repositório que já existe no GitHub é
+// deste projeto. Sem TTY não há como confirmar: só com --existing.
+func confirmExistingRepo(fullName string) bool {
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return false
+	}
+
+	confirmed := false
+	confirm := huh.NewConfirm().
+		Title(fmt.Sprintf("%s já existe no GitHub. Usar como origin e enviar o código?", fullName)).
+		Description("Só confirme se o repositório for deste projeto").
+		Affirmative("Sim").
+		Negative("Não").
+		Value(&confirmed)
+	if err := huh.NewForm(huh.NewGroup(confirm)).Run(); err != nil {
+		return false
+	}
+	return confirmed
+}
+
+// locateLocalProject acha a raiz do projeto atual e o nome, a descrição e a
+// org do repositório. Sem .algarys.toml, usa a raiz do repositório git.
+func locateLocalProject(orgFromFlag bool) (root, name, description, org string, err error) {
+	org = repoOrg
+	root, manifest, err := loadCurrentProject()
+	switch {
+	case err == nil:
+		name, description = manifest.Project.Name, manifest.Project.Description
+		if !orgFromFlag && manifest.Project.GitHubOrg != "" {
+			org = manifest.Project.GitHubOrg
+		}
+	case errors.Is(err, errNotAlgarysProject):
+		wd, _ := os.Getwd()
+		if root, err = runGit(wd, "rev-parse", "--show-toplevel"); err != nil {
+			return "", "", "", "", errNotAlgarysProject
+		}
+		name = filepath.Base(root)
+	default:
+		return "", "", "", "", err
+	}
+
+	if repoCreateName != "" {
+		name = repoCreateName
+	}
+	if repoCreateDesc != "" {
+		description = repoCreateDesc
+	}
+	name = normalizeProjectName(name)
+	if !pep508Name.MatchString(name) {
+		return "", "", "", "", fmt.Errorf("nome do projeto inválido: %s (use --name)", name)
+	}
+	return root, name, description, org, nil
+}
+
+var githubRemoteRe = regexp.MustCompile(`github\.com[:/]([^/]+)/([^/]+?)(\.git)?/?$`)
+
+// parseGitHubRemote extrai owner/repo de uma URL https ou ssh do GitHub
+func parseGitHubRemote(url string) (owner, repo string, ok bool) {
+	m := githubRemoteRe.FindStringSubmatch(url)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+// githubRemoteURL é a URL do repositório no protocolo configurado no gh
+func githubRemoteURL(fullName string) (string, error) {
+	field := ".clone_url"
+	if protocol, _ := exec.Command("gh", "config", "get", "git_protocol").Output(); strings.TrimSpace(string(protocol)) == "ssh" {
+		field = ".ssh_url"
+	}
+	return ghAPI("repos/"+fullName, field)
+}
+
+// splitRepoArg separa owner/repo; sem owner, usa a org padrão
+func splitRepoArg(arg, defaultOrg string) (org, repo string) {
+	if owner, name, ok := strings.Cut(arg, "/"); ok {
+		return owner, name
+	}
+	return defaultOrg, arg
+}
+
+// requireGitHubCLI verifica se o gh está instalado e autenticado
+func requireGitHubCLI() error {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return fmt.Errorf("GitHub CLI (gh) não encontrado")
+	}
+	if !IsLoggedIn() {
+		return fmt.Errorf("gh não autenticado (execute: algarys login)")
+	}
+	return nil
+}
+
+func exitWithError(msg string) {
+	fmt.Println(ui.RenderError(msg))
+	fmt.Println()
+	os.Exit(1)
+}
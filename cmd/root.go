@@ -72,7 +72,12 @@ func showWelcome() {
 		desc string
 	}{
 		{ui.IconRocket, "init", "Criar novo projeto Python"},
+		{ui.IconMagic, "add", "Adicionar componentes ao projeto"},
+		{ui.IconGear, "upgrade-project", "Atualizar projeto com o template"},
+		{ui.IconLock, "repo", "Criar e proteger repositórios da org no GitHub"},
+		{ui.IconCheck, "audit", "Verificar se os repositórios da org seguem os padrões"},
 		{"🎧", "transcribe", "Transcrever áudio para texto"},
+		{ui.IconGear, "config", "Ver a org e as convenções em uso"},
 		{ui.IconKey, "login", "Autenticar na Algarys"},
 		{ui.IconPackage, "update", "Atualizar o CLI"},
 		{ui.IconInfo, "version", "Mostrar versão do CLI"},
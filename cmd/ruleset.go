@@ -0,0 +1,411 @@
+package cmd
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Política padrão da org, versionada junto com o CLI
+//
+//go:embed policies/ruleset.yaml
+var defaultRulesetPolicy []byte
+
+// ID do app GitHub Actions: o status check só vale se vier dele
+const githubActionsAppID = 15368
+
+// RulesetPolicy é a política de proteção de branch aplicada como ruleset
+type RulesetPolicy struct {
+	Version       int               `yaml:"version"`
+	Name          string            `yaml:"name"` // nome do ruleset: chave para criar ou atualizar
+	Enforcement   string            `yaml:"enforcement"`
+	Branches      []string          `yaml:"branches"`
+	Reviews       ReviewPolicy      `yaml:"reviews"`
+	LinearHistory bool              `yaml:"linear_history"`
+	SignedCommits bool              `yaml:"signed_commits"`
+	StatusChecks  StatusCheckPolicy `yaml:"status_checks"`
+	BypassActors  []BypassActor     `yaml:"bypass_actors"`
+}
+
+type ReviewPolicy struct {
+	RequiredApprovals       int  `yaml:"required_approvals"`
+	DismissStaleReviews     bool `yaml:"dismiss_stale_reviews"`
+	RequireCodeOwnerReview  bool `yaml:"require_code_owner_review"`
+	RequireLastPushApproval bool `yaml:"require_last_push_approval"`
+	RequireThreadResolution bool `yaml:"require_thread_resolution"`
+}
+
+type StatusCheckPolicy struct {
+	Workflow string   `yaml:"workflow"` // checks só exigidos se o repositório tiver este arquivo
+	Checks   []string `yaml:"checks"`
+	Strict   bool     `yaml:"strict"`
+}
+
+// BypassActor é quem pode ignorar o ruleset
+type BypassActor struct {
+	Type string `yaml:"type"` // organization_admin, repository_role, team ou integration
+	Role string `yaml:"role"` // repository_role
+	Team string `yaml:"team"` // team (slug)
+	ID   int    `yaml:"id"`   // integration
+	Mode string `yaml:"mode"` // always (padrão) ou pull_request
+}
+
+// IDs fixos dos papéis de repositório na API de rulesets
+var repositoryRoleIDs = map[string]int{"maintain": 2, "write": 4, "admin": 5}
+
+// loadRulesetPolicy lê a política de path ou, vazio, a embutida no CLI
+func loadRulesetPolicy(path string) (*RulesetPolicy, error) {
+	data := defaultRulesetPolicy
+	source := "política embutida"
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("erro ao ler a política: %v", err)
+		}
+		source = path
+	}
+
+	var policy RulesetPolicy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("%s inválida: %v", source, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", source, err)
+	}
+	return &policy, nil
+}
+
+func (p *RulesetPolicy) validate() error {
+	if p.Version != 1 {
+		return fmt.Errorf("versão da política não suportada: %d", p.Version)
+	}
+	if p.Name == "" {
+		return fmt.Errorf("'name' é obrigatório")
+	}
+	if len(p.Branches) == 0 {
+		return fmt.Errorf("'branches' é obrigatório")
+	}
+	switch p.Enforcement {
+	case "":
+		p.Enforcement = "active"
+	case "active", "evaluate", "disabled":
+	default:
+		return fmt.Errorf("enforcement inválido: %s (use active, evaluate ou disabled)", p.Enforcement)
+	}
+
+	for i, a := range p.BypassActors {
+		switch a.Mode {
+		case "":
+			p.BypassActors[i].Mode = "always"
+		case "always", "pull_request":
+		default:
+			return fmt.Errorf("bypass_actors: mode inválido: %s", a.Mode)
+		}
+
+		switch a.Type {
+		case "organization_admin":
+		case "repository_role":
+			if _, ok := repositoryRoleIDs[a.Role]; !ok {
+				return fmt.Errorf("bypass_actors: role inválido: '%s' (use admin, maintain ou write)", a.Role)
+			}
+		case "team":
+			if a.Team == "" {
+				return fmt.Errorf("bypass_actors: 'team' sem o slug do time")
+			}
+		case "integration":
+			if a.ID == 0 {
+				return fmt.Errorf("bypass_actors: 'integration' sem o id do app")
+			}
+		default:
+			return fmt.Errorf("bypass_actors: tipo inválido: '%s'", a.Type)
+		}
+	}
+	return nil
+}
+
+// requiredChecks são os status checks exigidos num repositório que tem
+// (ou não) o workflow da política
+func (p *RulesetPolicy) requiredChecks(hasWorkflow bool) []string {
+	if p.StatusChecks.Workflow != "" && !hasWorkflow {
+		return nil
+	}
+	return p.StatusChecks.Checks
+}
+
+// describe resume as regras aplicadas
+func (p *RulesetPolicy) describe(requiredChecks []string) string {
+	parts := []string{fmt.Sprintf("PR com %d aprovação(ões)", p.Reviews.RequiredApprovals)}
+	if p.Reviews.RequireCodeOwnerReview {
+		parts = append(parts, "CODEOWNERS")
+	}
+	if p.LinearHistory {
+		parts = append(parts, "linear history")
+	}
+	if p.SignedCommits {
+		parts = append(parts, "commits assinados")
+	}
+	if len(requiredChecks) > 0 {
+		parts = append(parts, "check "+strings.Join(requiredChecks, ", "))
+	}
+	if len(p.BypassActors) > 0 {
+		parts = append(parts, fmt.Sprintf("%d bypass", len(p.BypassActors)))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// rulesetJSON monta o ruleset da política para a API do GitHub. Times do
+// bypass são resolvidos para IDs na org.
+func (p *RulesetPolicy) rulesetJSON(org string, requiredChecks []string) (string, error) {
+	rules := []map[string]any{
+		{
+			"type": "pull_request",
+			"parameters": map[string]any{
+				"required_approving_review_count":   p.Reviews.RequiredApprovals,
+				"dismiss_stale_reviews_on_push":     p.Reviews.DismissStaleReviews,
+				"require_code_owner_review":         p.Reviews.RequireCodeOwnerReview,
+				"require_last_push_approval":        p.Reviews.RequireLastPushApproval,
+				"required_review_thread_resolution": p.Reviews.RequireThreadResolution,
+			},
+		},
+	}
+	if p.LinearHistory {
+		rules = append(rules, map[string]any{"type": "required_linear_history"})
+	}
+	if p.SignedCommits {
+		rules = append(rules, map[string]any{"type": "required_signatures"})
+	}
+	if len(requiredChecks) > 0 {
+		checks := make([]map[string]any, 0, len(requiredChecks))
+		for _, c := range requiredChecks {
+			checks = append(checks, map[string]any{"context": c, "integration_id": githubActionsAppID})
+		}
+		rules = append(rules, map[string]any{
+			"type": "required_status_checks",
+			"parameters": map[string]any{
+				"strict_required_status_checks_policy": p.StatusChecks.Strict,
+				"required_status_checks":               checks,
+			},
+		})
+	}
+
+	bypass := make([]map[string]any, 0, len(p.BypassActors))
+	for _, a := range p.BypassActors {
+		actor, err := a.apiActor(org)
+		if err != nil {
+			return "", err
+		}
+		bypass = append(bypass, actor)
+	}
+
+	ruleset := map[string]any{
+		"name":        p.Name,
+		"target":      "branch",
+		"enforcement": p.Enforcement,
+		"conditions": map[string]any{
+			"ref_name": map[string]any{
+				"include": p.refIncludes(),
+				"exclude": []string{},
+			},
+		},
+		"rules":         rules,
+		"bypass_actors": bypass,
+	}
+
+	data, _ := json.MarshalIndent(ruleset, "", "\t") // só tipos simples, não falha
+	return string(data), nil
+}
+
+// refIncludes são as branches da política no formato da API: nomes simples
+// viram refs/heads/..., padrões especiais (~DEFAULT_BRANCH) ficam como estão
+func (p *RulesetPolicy) refIncludes() []string {
+	include := make([]string, 0, len(p.Branches))
+	for _, b := range p.Branches {
+		if !strings.HasPrefix(b, "~") && !strings.HasPrefix(b, "refs/") {
+			b = "refs/heads/" + b
+		}
+		include = append(include, b)
+	}
+	return include
+}
+
+// apiActor converte o ator da política no formato da API
+func (a BypassActor) apiActor(org string) (map[string]any, error) {
+	actor := map[string]any{"bypass_mode": a.Mode}
+	switch a.Type {
+	case "organization_admin":
+		actor["actor_type"] = "OrganizationAdmin"
+		actor["actor_id"] = 1
+	case "repository_role":
+		actor["actor_type"] = "RepositoryRole"
+		actor["actor_id"] = repositoryRoleIDs[a.Role]
+	case "integration":
+		actor["actor_type"] = "Integration"
+		actor["actor_id"] = a.ID
+	case "team":
+		id, err := ghAPI(fmt.Sprintf("orgs/%s/teams/%s", org, a.Team), ".id")
+		if err != nil {
+			return nil, fmt.Errorf("time '%s' não encontrado em %s: %v", a.Team, org, err)
+		}
+		actor["actor_type"] = "Team"
+		actor["actor_id"], _ = strconv.Atoi(id)
+	}
+	return actor, nil
+}
+
+// rulesetAPICommand monta a chamada que cria (id vazio) ou atualiza o
+// ruleset (JSON via stdin)
+func rulesetAPICommand(repoName, org, id string) []string {
+	path, method := fmt.Sprintf("/repos/%s/%s/rulesets", org, repoName), "POST"
+	if id != "" {
+		path, method = path+"/"+id, "PUT"
+	}
+	return []string{"gh", "api", path,
+		"-X", method,
+		"-H", "Accept: application/vnd.github+json",
+		"--input", "-",
+	}
+}
+
+// findRuleset devolve o ID do ruleset do próprio repositório (não herdado
+// da org) com o nome informado, ou vazio se não existir
+func findRuleset(api githubAPI, repoName, org, name string) (string, error) {
+	var rulesets []struct {
+		ID         int64  `json:"id"`
+		SourceType string `json:"source_type"`
+		Name       string `json:"name"`
+	}
+	if err := api.get(fmt.Sprintf("repos/%s/%s/rulesets", org, repoName), &rulesets); err != nil {
+		return "", err
+	}
+	for _, rs := range rulesets {
+		if rs.SourceType == "Repository" && rs.Name == name {
+			return strconv.FormatInt(rs.ID, 10), nil
+		}
+	}
+	return "", nil
+}
+
+// applyRuleset cria ou atualiza (pelo nome) o ruleset da política no
+// repositório. Devolve se o ruleset já existia.
+func applyRuleset(repoName, org string, policy *RulesetPolicy, requiredChecks []string) (updated bool, err error) {
+	payload, err := policy.rulesetJSON(org, requiredChecks)
+	if err != nil {
+		return false, err
+	}
+	id, err := findRuleset(ghCLI{}, repoName, org, policy.Name)
+	if err != nil {
+		return false, fmt.Errorf("erro ao listar rulesets: %v", err)
+	}
+	return id != "", writeRuleset(repoName, org, id, payload)
+}
+
+// writeRuleset envia o JSON do ruleset: cria (id vazio) ou substitui o existente
+func writeRuleset(repoName, org, id, payload string) error {
+	return runCommand("", strings.NewReader(payload), rulesetAPICommand(repoName, org, id))
+}
+
+// remoteRuleset é o ruleset como a API do GitHub devolve
+type remoteRuleset struct {
+	Enforcement string `json:"enforcement"`
+	Conditions  struct {
+		RefName struct {
+			Include []string `json:"include"`
+		} `json:"ref_name"`
+	} `json:"conditions"`
+	Rules []struct {
+		Type       string          `json:"type"`
+		Parameters json.RawMessage `json:"parameters"`
+	} `json:"rules"`
+}
+
+// fetchRuleset lê o ruleset id do repositório
+func fetchRuleset(api githubAPI, repoName, org, id string) (*remoteRuleset, error) {
+	var rs remoteRuleset
+	if err := api.get(fmt.Sprintf("repos/%s/%s/rulesets/%s", org, repoName, id), &rs); err != nil {
+		return nil, err
+	}
+	return &rs, nil
+}
+
+// drift lista onde o ruleset do repositório ficou mais fraco que a política.
+// Regras a mais no repositório não contam; os bypass actors não são
+// comparados (a API só os devolve para admins).
+func (p *RulesetPolicy) drift(rs *remoteRuleset, requiredChecks []string) []string {
+	var problems []string
+	if rs.Enforcement != p.Enforcement {
+		problems = append(problems, fmt.Sprintf("enforcement %s (política: %s)", rs.Enforcement, p.Enforcement))
+	}
+	for _, ref := range p.refIncludes() {
+		if !containsString(rs.Conditions.RefName.Include, ref) {
+			problems = append(problems, "não cobre "+ref)
+		}
+	}
+
+	rules := map[string]json.RawMessage{}
+	for _, r := range rs.Rules {
+		rules[r.Type] = r.Parameters
+	}
+
+	if params, ok := rules["pull_request"]; !ok {
+		problems = append(problems, "sem exigência de PR")
+	} else {
+		var pr struct {
+			Approvals       int  `json:"required_approving_review_count"`
+			DismissStale    bool `json:"dismiss_stale_reviews_on_push"`
+			CodeOwner       bool `json:"require_code_owner_review"`
+			LastPush        bool `json:"require_last_push_approval"`
+			ThreadsResolved bool `json:"required_review_thread_resolution"`
+		}
+		_ = json.Unmarshal(params, &pr)
+		if pr.Approvals < p.Reviews.RequiredApprovals {
+			problems = append(problems, fmt.Sprintf("%d aprovação(ões) (política: %d)", pr.Approvals, p.Reviews.RequiredApprovals))
+		}
+		if p.Reviews.RequireCodeOwnerReview && !pr.CodeOwner {
+			problems = append(problems, "sem review de CODEOWNERS")
+		}
+		if p.Reviews.DismissStaleReviews && !pr.DismissStale {
+			problems = append(problems, "aprovações não caem com novos pushes")
+		}
+		if p.Reviews.RequireLastPushApproval && !pr.LastPush {
+			problems = append(problems, "sem aprovação do último push")
+		}
+		if p.Reviews.RequireThreadResolution && !pr.ThreadsResolved {
+			problems = append(problems, "sem resolução das conversas")
+		}
+	}
+
+	if _, ok := rules["required_linear_history"]; p.LinearHistory && !ok {
+		problems = append(problems, "sem histórico linear")
+	}
+	if _, ok := rules["required_signatures"]; p.SignedCommits && !ok {
+		problems = append(problems, "sem commits assinados")
+	}
+
+	if len(requiredChecks) > 0 {
+		var sc struct {
+			Checks []struct {
+				Context string `json:"context"`
+			} `json:"required_status_checks"`
+			Strict bool `json:"strict_required_status_checks_policy"`
+		}
+		_ = json.Unmarshal(rules["required_status_checks"], &sc)
+		var contexts []string
+		for _, c := range sc.Checks {
+			contexts = append(contexts, c.Context)
+		}
+		for _, c := range requiredChecks {
+			if !containsString(contexts, c) {
+				problems = append(problems, "sem o status check "+c)
+			}
+		}
+		if p.StatusChecks.Strict && !sc.Strict {
+			problems = append(problems, "status checks não exigem branch atualizada")
+		}
+	}
+	return problems
+}
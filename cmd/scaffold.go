@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// Template padrão de projeto, embutido no binário.
+// Arquivos terminados em .tmpl são renderizados com text/template (e perdem
+// a extensão); os demais são copiados como estão. Os nomes de arquivos e
+// pastas também são templates: um segmento que renderiza vazio é omitido,
+// junto com tudo que estiver abaixo dele.
+//
+//go:embed all:templates/default
+var defaultTemplateFS embed.FS
+
+const defaultTemplateRoot = "templates/default"
+const templateExt = ".tmpl"
+
+// TemplateContext são os dados disponíveis para os templates
+type TemplateContext struct {
+	Name          string // nome do projeto (kebab-case)
+	Module        string // nome do pacote Python (snake_case)
+	Description   string
+	PythonVersion string
+	Modules       map[string]bool // módulos selecionados (ex: .Modules.temporal)
+	LLMProvider   string          // provedor de LLM padrão (módulo ai)
+	TemporalAuth  string          // local, mtls ou api-key (módulo temporal)
+	Options       map[string]any
+}
+
+// RenderedFile é um arquivo do projeto já renderizado, com caminho relativo
+// à raiz do projeto (sempre com "/")
+type RenderedFile struct {
+	Path    string
+	Content []byte
+	Mode    fs.FileMode
+}
+
+func newTemplateContext(config ProjectConfig, moduleName string) TemplateContext {
+	return TemplateContext{
+		Name:          config.Name,
+		Module:        moduleName,
+		Description:   config.Description,
+		PythonVersion: config.PythonVersion,
+		Modules:       modulesSet(config.Modules),
+		LLMProvider:   optionOrDefault(config.LLMProvider, llmProviders),
+		TemporalAuth:  optionOrDefault(config.TemporalAuth, temporalAuthModes),
+		Options:       config.Options,
+	}
+}
+
+func defaultTemplate() fs.FS {
+	sub, err := fs.Sub(defaultTemplateFS, defaultTemplateRoot)
+	if err != nil {
+		panic(err) // caminho fixo, embutido em tempo de compilação
+	}
+	return sub
+}
+
+// renderTemplate percorre o template e devolve os arquivos renderizados em memória
+func renderTemplate(fsys fs.FS, ctx TemplateContext) ([]RenderedFile, error) {
+	var files []RenderedFile
+
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == "." {
+			return nil
+		}
+		// Metadados de templates externos não fazem parte do projeto
+		if d.Name() == ".git" || p == templateManifestFile {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		target, err := renderPath(p, ctx)
+		if err != nil {
+			return err
+		}
+		if target == "" {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+
+		if strings.HasSuffix(target, templateExt) {
+			target = strings.TrimSuffix(target, templateExt)
+			content, err = renderContent(p, content, ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		files = append(files, RenderedFile{Path: target, Content: content, Mode: 0644})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// renderPath renderiza cada segmento do caminho; retorna "" se algum
+// segmento ficar vazio (arquivo/pasta desabilitado)
+func renderPath(p string, ctx TemplateContext) (string, error) {
+	segments := strings.Split(p, "/")
+	for i, seg := range segments {
+		if !strings.Contains(seg, "{{") {
+			continue
+		}
+		rendered, err := renderContent(p, []byte(seg), ctx)
+		if err != nil {
+			return "", err
+		}
+		if len(bytes.TrimSpace(rendered)) == 0 {
+			return "", nil
+		}
+		segments[i] = string(rendered)
+	}
+	return path.Join(segments...), nil
+}
+
+// templateFuncs são as funções disponíveis nos templates, além das nativas
+var templateFuncs = template.FuncMap{
+	"toml": tomlString,
+}
+
+// tomlString devolve s como string básica do TOML, já entre aspas
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func renderContent(name string, content []byte, data any) ([]byte, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Funcs(templateFuncs).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("template %s inválido: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("erro ao renderizar %s: %v", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeRenderedFiles grava os arquivos renderizados dentro de root
+func writeRenderedFiles(root string, files []RenderedFile) error {
+	for _, f := range files {
+		dest := filepath.Join(root, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dest, f.Content, f.Mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateProjectFiles roda toda a geração em memória: template renderizado
+// mais o manifesto do projeto
+func generateProjectFiles(config ProjectConfig, moduleName string, tpl *Template) ([]RenderedFile, error) {
+	files, err := renderTemplate(tpl.FS, newTemplateContext(config, moduleName))
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := encodeProjectManifest(newProjectManifest(config, moduleName, tpl))
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, RenderedFile{Path: projectManifestFile, Content: manifest, Mode: 0644})
+
+	return files, nil
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+func TestRenderPyprojectQuotesDescription(t *testing.T) {
+	descriptions := []string{
+		`Agente "triage" de tickets`,
+		`Caminhos C:\temp e \n literais`,
+		"Duas\nlinhas\tcom tab",
+	}
+	for _, desc := range descriptions {
+		config := ProjectConfig{Name: "demo", Description: desc, PythonVersion: "3.12", Modules: moduleKeys()}
+		files, err := renderTemplate(defaultTemplate(), newTemplateContext(config, "demo"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var pyproject struct {
+			Project struct {
+				Description string `toml:"description"`
+			} `toml:"project"`
+		}
+		if _, err := toml.Decode(string(renderedByPath(files)["pyproject.toml"]), &pyproject); err != nil {
+			t.Fatalf("pyproject.toml inválido com descrição %q: %v", desc, err)
+		}
+		if pyproject.Project.Description != desc {
+			t.Errorf("description = %q, esperado %q", pyproject.Project.Description, desc)
+		}
+	}
+}
+
+var (
+	// Chaves do .env.example, inclusive as comentadas (# CHAVE=...)
+	envKeyPattern = regexp.MustCompile(`(?m)^#? ?([A-Z][A-Z0-9_]*)=`)
+	// Campos da classe Settings (quatro espaços de indentação)
+	settingsFieldPattern = regexp.MustCompile(`(?m)^    ([a-z][a-z0-9_]*): `)
+)
+
+// Variáveis do .env.example lidas só pelo docker compose, não pela aplicação
+var composeOnlyEnvKeys = map[string]bool{"POSTGRES_USER": true, "POSTGRES_PASSWORD": true, "POSTGRES_DB": true}
+
+func TestSettingsMatchEnvExample(t *testing.T) {
+	for _, preset := range projectPresets {
+		for _, auth := range temporalAuthModes {
+			modules, err := presetModules(preset.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			config := ProjectConfig{Name: "demo", PythonVersion: "3.12", Modules: modules, TemporalAuth: auth.value}
+			files, err := renderTemplate(defaultTemplate(), newTemplateContext(config, "demo"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			byPath := renderedByPath(files)
+
+			envKeys := map[string]bool{}
+			for _, m := range envKeyPattern.FindAllStringSubmatch(string(byPath[".env.example"]), -1) {
+				if !composeOnlyEnvKeys[m[1]] {
+					envKeys[m[1]] = true
+				}
+			}
+			fields := map[string]bool{}
+			settings := string(byPath["demo/settings.py"])
+			if i := strings.Index(settings, "class Settings("); i >= 0 {
+				for _, m := range settingsFieldPattern.FindAllStringSubmatch(settings[i:], -1) {
+					fields[strings.ToUpper(m[1])] = true
+				}
+			}
+
+			var missing []string
+			for key := range envKeys {
+				if !fields[key] {
+					missing = append(missing, key+" sem campo em Settings")
+				}
+			}
+			for key := range fields {
+				if !envKeys[key] {
+					missing = append(missing, key+" fora do .env.example")
+				}
+			}
+			sort.Strings(missing)
+			if len(missing) > 0 {
+				t.Errorf("preset %s, temporal %s: %s", preset.key, auth.value, strings.Join(missing, "; "))
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+)
+
+// projectStaging é a pasta temporária onde o projeto é montado antes de ir
+// para o destino. Fica ao lado do destino para que o rename seja atômico.
+type projectStaging struct {
+	Dir    string
+	Target string
+}
+
+func newProjectStaging(target string) (*projectStaging, error) {
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp(filepath.Dir(abs), "."+filepath.Base(abs)+".algarys-*")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório temporário: %v", err)
+	}
+	// MkdirTemp cria com 0700; o projeto final deve ter a permissão normal
+	if err := os.Chmod(dir, 0755); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	return &projectStaging{Dir: dir, Target: abs}, nil
+}
+
+// Commit move o projeto montado para o destino
+func (s *projectStaging) Commit() error {
+	if _, err := os.Lstat(s.Target); !os.IsNotExist(err) {
+		return fmt.Errorf("diretório '%s' foi criado durante a geração", filepath.Base(s.Target))
+	}
+	if err := os.Rename(s.Dir, s.Target); err != nil {
+		return fmt.Errorf("erro ao mover o projeto para %s: %v", s.Target, err)
+	}
+	return nil
+}
+
+// Rollback apaga a pasta temporária (sem efeito depois do Commit)
+func (s *projectStaging) Rollback() {
+	os.RemoveAll(s.Dir)
+}
+
+// onInterrupt executa cleanup se o usuário interromper (Ctrl+C) e encerra.
+// A função devolvida desliga o tratamento.
+func onInterrupt(cleanup func()) (stop func()) {
+	sigs := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-sigs:
+			cleanup()
+			fmt.Println()
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigs)
+		close(done)
+	}
+}
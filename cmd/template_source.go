@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const templatesCacheDir = "templates"
+const templateManifestFile = "algarys-template.yaml"
+const defaultTemplateID = "default"
+
+// Template é uma fonte de template já resolvida e pronta para renderizar
+type Template struct {
+	ID       string // "default", URL git ou caminho local
+	Ref      string // ref pedida (tag, branch ou commit)
+	Commit   string // commit resolvido, quando a fonte é git
+	Repo     string // raiz do repositório git de um template local
+	Subdir   string // pasta do template dentro de Repo ("" na raiz)
+	Dir      string // diretório local do template (vazio no embutido)
+	FS       fs.FS
+	Manifest TemplateManifest
+}
+
+// TemplateManifest é o algarys-template.yaml na raiz do template
+type TemplateManifest struct {
+	Name        string             `yaml:"name"`
+	Description string             `yaml:"description"`
+	Root        string             `yaml:"root"` // pasta com a árvore (padrão: template/ se existir)
+	Questions   []TemplateQuestion `yaml:"questions"`
+	PreGen      []TemplateHook     `yaml:"pre_gen"`  // rodam antes de gerar os arquivos
+	PostGen     []TemplateHook     `yaml:"post_gen"` // rodam no projeto já criado
+}
+
+// TemplateQuestion é uma pergunta extra declarada pelo template.
+// A resposta fica disponível em .Options.<key>
+type TemplateQuestion struct {
+	Key         string   `yaml:"key"`
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description"`
+	Type        string   `yaml:"type"` // input (padrão), confirm ou select
+	Default     any      `yaml:"default"`
+	Options     []string `yaml:"options"`
+	Required    bool     `yaml:"required"`
+}
+
+// resolveTemplate carrega o template indicado em source: vazio usa o
+// embutido, uma pasta de template é usada direto e o resto é tratado como
+// URL git com ref opcional (url@ref); caminhos inexistentes são um erro
+func resolveTemplate(source string) (*Template, error) {
+	if source == "" || source == defaultTemplateID {
+		// O template embutido acompanha a versão do CLI
+		tpl := &Template{ID: defaultTemplateID, Ref: "v" + Version, FS: defaultTemplate()}
+		if GitCommit != "none" {
+			tpl.Commit = GitCommit
+		}
+		return tpl, nil
+	}
+
+	if isLocalTemplate(source) {
+		dir, err := filepath.Abs(source)
+		if err != nil {
+			return nil, err
+		}
+		tpl := &Template{ID: dir, Dir: dir}
+		if commit, err := runGit(dir, "rev-parse", "HEAD"); err == nil {
+			// O template pode ser uma subpasta do repositório: o upgrade
+			// clona a raiz e lê a versão antiga dessa subpasta
+			tpl.Commit = commit
+			tpl.Repo, _ = runGit(dir, "rev-parse", "--show-toplevel")
+			prefix, _ := runGit(dir, "rev-parse", "--show-prefix")
+			tpl.Subdir = strings.TrimSuffix(prefix, "/")
+		}
+		return tpl, tpl.load()
+	}
+
+	url, ref := parseTemplateSource(source)
+	if isLocalPath(url) {
+		// Um caminho que não existe não é URL git: evita o erro confuso do clone
+		if _, err := os.Stat(url); err != nil {
+			return nil, fmt.Errorf("diretório não encontrado: %s", url)
+		}
+		// O ID vai para o .algarys.toml: relativo, não seria achado de dentro do projeto
+		abs, err := filepath.Abs(url)
+		if err != nil {
+			return nil, err
+		}
+		url = abs
+	}
+	dir, commit, err := fetchGitTemplate(url, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	tpl := &Template{ID: url, Ref: ref, Commit: commit, Dir: dir}
+	return tpl, tpl.load()
+}
+
+// isLocalTemplate diz se source é uma pasta de template local (e não um
+// repositório git bare, que é clonado como qualquer URL)
+func isLocalTemplate(source string) bool {
+	info, err := os.Stat(source)
+	if err != nil || !info.IsDir() {
+		return false
+	}
+	_, headErr := os.Stat(filepath.Join(source, "HEAD"))
+	_, objectsErr := os.Stat(filepath.Join(source, "objects"))
+	return headErr != nil || objectsErr != nil
+}
+
+// isLocalPath diz se source é um caminho no disco e não uma URL git
+// (https://, ssh://, file:// ou host:caminho)
+func isLocalPath(source string) bool {
+	if strings.Contains(source, "://") {
+		return false
+	}
+	return filepath.IsAbs(source) || !strings.Contains(source, ":")
+}
+
+// parseTemplateSource separa a ref do final da URL:
+// git@github.com:algarys/tpl-agent.git@v2 -> (git@github.com:algarys/tpl-agent.git, v2)
+func parseTemplateSource(source string) (url, ref string) {
+	at := strings.LastIndex(source, "@")
+	sep := strings.LastIndexAny(source, "/:")
+	if at > sep && at < len(source)-1 {
+		return source[:at], source[at+1:]
+	}
+	return source, ""
+}
+
+// load lê o manifesto (opcional) e monta o FS da árvore do template
+func (t *Template) load() error {
+	data, err := os.ReadFile(filepath.Join(t.Dir, templateManifestFile))
+	if err == nil {
+		if err := yaml.Unmarshal(data, &t.Manifest); err != nil {
+			return fmt.Errorf("%s inválido: %v", templateManifestFile, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	root := t.Manifest.Root
+	if root == "" {
+		if info, err := os.Stat(filepath.Join(t.Dir, "template")); err == nil && info.IsDir() {
+			root = "template"
+		} else {
+			root = "."
+		}
+	}
+
+	treeDir := filepath.Join(t.Dir, root)
+	if info, err := os.Stat(treeDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("pasta do template não encontrada: %s", root)
+	}
+	t.FS = os.DirFS(treeDir)
+
+	for _, q := range t.Manifest.Questions {
+		if q.Key == "" {
+			return fmt.Errorf("%s: pergunta sem 'key'", templateManifestFile)
+		}
+	}
+	for _, h := range append(append([]TemplateHook(nil), t.Manifest.PreGen...), t.Manifest.PostGen...) {
+		if err := h.validate(t.Dir); err != nil {
+			return fmt.Errorf("%s: %v", templateManifestFile, err)
+		}
+	}
+	return nil
+}
+
+// fetchGitTemplate clona (ou atualiza) o template no cache e faz checkout da ref.
+// Sem rede, usa o que já estiver no cache. gitArgs vão antes do clone e do
+// fetch (ex: credenciais).
+func fetchGitTemplate(url, ref string, gitArgs ...string) (dir, commit string, err error) {
+	dir, err = templateCachePath(url)
+	if err != nil {
+		return "", "", err
+	}
+
+	if _, statErr := os.Stat(filepath.Join(dir, ".git")); os.IsNotExist(statErr) {
+		if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+			return "", "", err
+		}
+		if _, err := runGit("", append(gitArgs, "clone", "-q", url, dir)...); err != nil {
+			os.RemoveAll(dir)
+			return "", "", fmt.Errorf("erro ao clonar template %s: %v", url, err)
+		}
+	} else {
+		// Falha aqui (ex: offline) não é fatal: segue com o cache
+		runGit(dir, append(gitArgs, "fetch", "-q", "--tags", "--force", "origin")...)
+	}
+
+	commit, err = resolveTemplateRef(dir, ref)
+	if err != nil {
+		return "", "", err
+	}
+	if _, err := runGit(dir, "checkout", "-q", "--detach", commit); err != nil {
+		return "", "", fmt.Errorf("erro ao fazer checkout de %s: %v", ref, err)
+	}
+
+	return dir, commit, nil
+}
+
+// resolveTemplateRef converte ref (branch, tag ou commit) em commit.
+// Branches são resolvidas pelo remoto para pegar a versão mais recente.
+func resolveTemplateRef(dir, ref string) (string, error) {
+	candidates := []string{"origin/HEAD"}
+	if ref != "" {
+		candidates = []string{"origin/" + ref, ref}
+	}
+
+	for _, c := range candidates {
+		if commit, err := runGit(dir, "rev-parse", "--verify", "-q", c+"^{commit}"); err == nil {
+			return commit, nil
+		}
+	}
+	return "", fmt.Errorf("ref '%s' não encontrada no template", ref)
+}
+
+var cacheNameSanitizer = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// templateCachePath retorna ~/.algarys/templates/<nome>-<hash>: o nome do
+// repositório deixa o cache legível e o hash da URL normalizada inteira
+// (com o protocolo) evita que URLs diferentes dividam o mesmo clone
+func templateCachePath(url string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	normalized := strings.TrimSuffix(strings.TrimRight(strings.TrimSpace(url), "/"), ".git")
+	if isLocalPath(normalized) {
+		if abs, err := filepath.Abs(normalized); err == nil {
+			normalized = abs
+		}
+	}
+	sum := sha256.Sum256([]byte(normalized))
+
+	name := normalized[strings.LastIndexAny(normalized, "/:\\")+1:]
+	name = strings.Trim(cacheNameSanitizer.ReplaceAllString(name, "_"), "_.")
+	if name == "" {
+		name = "template"
+	}
+
+	return filepath.Join(homeDir, algarysDir, templatesCacheDir, name+"-"+hex.EncodeToString(sum[:6])), nil
+}
+
+// githubCredentialArgs fazem o git se autenticar no GitHub pelo gh, como o
+// `gh repo clone`: repositórios privados da org clonam com o login do CLI
+func githubCredentialArgs() []string {
+	gh, err := exec.LookPath("gh")
+	if err != nil {
+		return nil
+	}
+	return []string{
+		"-c", "credential.https://github.com.helper=",
+		"-c", fmt.Sprintf("credential.https://github.com.helper=!%q auth git-credential", gh),
+	}
+}
+
+// runGit executa git em dir e retorna o stdout sem espaços nas pontas
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
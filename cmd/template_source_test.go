@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// gitTest roda git em dir com autor fixo, falhando o teste em erro
+func gitTest(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// bareTemplateRepo cria um repositório bare com duas versões de um
+// template: a tag v1 e o HEAD da main. Devolve o caminho e os commits.
+func bareTemplateRepo(t *testing.T) (bare, v1, head string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git não encontrado")
+	}
+
+	work := t.TempDir()
+	gitTest(t, work, "init", "-q", "-b", "main")
+	writeTemplateFile := func(content string) {
+		if err := os.MkdirAll(filepath.Join(work, "template"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(work, "template", "VERSION"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeTemplateFile("v1")
+	gitTest(t, work, "add", ".")
+	gitTest(t, work, "commit", "-q", "-m", "v1")
+	gitTest(t, work, "tag", "v1")
+	v1 = gitTest(t, work, "rev-parse", "HEAD")
+
+	writeTemplateFile("v2")
+	gitTest(t, work, "commit", "-q", "-am", "v2")
+	head = gitTest(t, work, "rev-parse", "HEAD")
+
+	bare = filepath.Join(t.TempDir(), "tpl.git")
+	gitTest(t, "", "clone", "-q", "--bare", work, bare)
+	return bare, v1, head
+}
+
+func templateVersion(t *testing.T, tpl *Template) string {
+	t.Helper()
+	data, err := fs.ReadFile(tpl.FS, "VERSION")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestResolveGitTemplate(t *testing.T) {
+	bare, v1, head := bareTemplateRepo(t)
+	t.Setenv("HOME", t.TempDir())
+
+	tpl, err := resolveTemplate(bare)
+	if err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+	if tpl.Commit != head || templateVersion(t, tpl) != "v2" {
+		t.Errorf("clone: commit %s com %q, esperado %s com v2", tpl.Commit, templateVersion(t, tpl), head)
+	}
+	cacheDir := tpl.Dir
+
+	// Na segunda vez o clone em cache é reaproveitado (só recebe fetch)
+	marker := filepath.Join(cacheDir, "cache-marker")
+	if err := os.WriteFile(marker, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	tpl, err = resolveTemplate(bare + "@v1")
+	if err != nil {
+		t.Fatalf("cache: %v", err)
+	}
+	if _, err := os.Stat(marker); tpl.Dir != cacheDir || err != nil {
+		t.Errorf("cache: usou %s, esperado o clone existente em %s", tpl.Dir, cacheDir)
+	}
+	if tpl.Commit != v1 || tpl.Ref != "v1" || templateVersion(t, tpl) != "v1" {
+		t.Errorf("ref v1: commit %s com %q, esperado %s com v1", tpl.Commit, templateVersion(t, tpl), v1)
+	}
+}
+
+func TestResolveTemplateMissingLocalPath(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "nao-existe")
+	_, err := resolveTemplate(missing)
+	if err == nil || !strings.Contains(err.Error(), "diretório não encontrado") {
+		t.Errorf("resolveTemplate(%s) = %v, esperado diretório não encontrado", missing, err)
+	}
+}
+
+func TestTemplateCachePathIsPerURL(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	urls := []string{
+		"https://host/x.git",
+		"git@host:x.git",
+		"ssh://git@host/x.git",
+		"file:///srv/x.git",
+		"https://other/x.git",
+	}
+	seen := map[string]string{}
+	for _, u := range urls {
+		path, err := templateCachePath(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if other, ok := seen[path]; ok {
+			t.Errorf("%s e %s dividem o cache %s", u, other, path)
+		}
+		seen[path] = u
+	}
+
+	a, _ := templateCachePath("https://host/x.git")
+	b, _ := templateCachePath("https://host/x/")
+	if a != b {
+		t.Errorf("mesma URL normalizada em caches diferentes: %s e %s", a, b)
+	}
+}
+
+// chdirTest muda o diretório atual até o fim do teste
+func chdirTest(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestRelativeGitTemplateUpgradesFromProject(t *testing.T) {
+	bare, v1, _ := bareTemplateRepo(t)
+	t.Setenv("HOME", t.TempDir())
+
+	work := filepath.Join(filepath.Dir(bare), "work")
+	if err := os.Mkdir(work, 0755); err != nil {
+		t.Fatal(err)
+	}
+	chdirTest(t, work)
+
+	tpl, err := resolveTemplate("../tpl.git@v1")
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if tpl.ID != bare {
+		t.Errorf("ID = %s, esperado o caminho absoluto %s", tpl.ID, bare)
+	}
+	files, err := generateProjectFiles(ProjectConfig{Name: "demo"}, "demo", tpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeRenderedFiles("demo", files); err != nil {
+		t.Fatal(err)
+	}
+
+	// O upgrade roda de dentro do projeto, com outro diretório atual
+	chdirTest(t, filepath.Join(work, "demo"))
+	root, manifest, err := loadCurrentProject()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Template.Commit != v1 {
+		t.Errorf("commit no manifesto = %s, esperado %s", manifest.Template.Commit, v1)
+	}
+	oldFiles, _, newFiles, err := renderTemplateRevisions(*manifest, "main")
+	if err != nil {
+		t.Fatalf("upgrade: %v", err)
+	}
+	changes, err := planUpgrade(root, oldFiles, newFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Path != "VERSION" || string(changes[0].Result) != "v2" {
+		t.Errorf("changes = %+v, esperado VERSION atualizado para v2", changes)
+	}
+}
@@ -0,0 +1,16 @@
+"""Templates de prompts."""
+
+SYSTEM_PROMPT = """Você é um assistente útil da Algarys.
+Responda de forma clara e objetiva.
+"""
+
+AGENT_PROMPT = """Você é um agente especializado em {domain}.
+
+Contexto:
+{context}
+
+Ferramentas disponíveis:
+{tools}
+
+Tarefa: {task}
+"""
@@ -0,0 +1,55 @@
+"""Interface comum dos provedores de LLM, no formato neutro entre APIs."""
+from abc import ABC, abstractmethod
+from dataclasses import dataclass, field
+from typing import Any, Literal
+
+Role = Literal["system", "user", "assistant", "tool"]
+
+
+@dataclass(frozen=True)
+class ToolSpec:
+    """Ferramenta oferecida ao modelo (parâmetros em JSON Schema)."""
+
+    name: str
+    description: str
+    parameters: dict[str, Any]
+
+
+@dataclass(frozen=True)
+class ToolCall:
+    """Chamada de ferramenta pedida pelo modelo."""
+
+    id: str
+    name: str
+    arguments: dict[str, Any]
+
+
+@dataclass(frozen=True)
+class Message:
+    """Mensagem da conversa."""
+
+    role: Role
+    content: str = ""
+    tool_calls: list[ToolCall] = field(default_factory=list)  # role="assistant"
+    tool_call_id: str | None = None  # role="tool"
+
+
+@dataclass(frozen=True)
+class Completion:
+    """Resposta do modelo: texto e/ou chamadas de ferramentas."""
+
+    content: str = ""
+    tool_calls: list[ToolCall] = field(default_factory=list)
+
+
+class LLMProvider(ABC):
+    """Interface base para provedores de LLM."""
+
+    @abstractmethod
+    async def complete(
+        self,
+        messages: list[Message],
+        tools: list[ToolSpec] | None = None,
+    ) -> Completion:
+        """Gera a próxima resposta da conversa."""
+        ...
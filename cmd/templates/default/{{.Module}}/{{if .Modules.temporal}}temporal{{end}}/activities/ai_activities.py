@@ -0,0 +1,18 @@
+"""Activities do Temporal."""
+from typing import Any
+
+from temporalio import activity
+
+
+@activity.defn
+async def process_data(data: dict[str, Any]) -> dict[str, Any]:
+    """Activity para processar dados."""
+    # Implementar lógica de processamento
+    return {"status": "processed", "data": data}
+
+
+@activity.defn
+async def call_ai_agent(prompt: str, agent_name: str) -> str:
+    """Activity para chamar um agente de IA."""
+    # Implementar chamada ao agente
+    return f"Response from {agent_name}"
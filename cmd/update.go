@@ -16,8 +16,6 @@ import (
 )
 
 const (
-	repoOwner       = "algarys"
-	repoName        = "algarys_cli"
 	checkInterval   = 24 * time.Hour
 	cacheFile       = ".algarys_update_check"
 )
@@ -30,7 +28,9 @@ type GitHubRelease struct {
 var updateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Atualiza o Algarys CLI para a última versão",
-	Run:   runUpdate,
+	// Roda mesmo com a configuração inválida: atualizar pode ser a correção
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+	Run:              runUpdate,
 }
 
 func init() {
@@ -90,7 +90,7 @@ func runUpdate(cmd *cobra.Command, args []string) {
 			"Para atualizar manualmente, execute:",
 		))
 		fmt.Println(lipgloss.NewStyle().Foreground(ui.Primary).PaddingLeft(4).Render(
-			"curl -fsSL https://raw.githubusercontent.com/algarys/algarys_cli/main/install.sh | bash",
+			installCommand(),
 		))
 		fmt.Println()
 		return
@@ -110,7 +110,7 @@ func runUpdate(cmd *cobra.Command, args []string) {
 			"Tente manualmente:",
 		))
 		fmt.Println(lipgloss.NewStyle().Foreground(ui.Primary).PaddingLeft(4).Render(
-			"curl -fsSL https://raw.githubusercontent.com/algarys/algarys_cli/main/install.sh | bash",
+			installCommand(),
 		))
 		return
 	}
@@ -131,10 +131,15 @@ func runUpdate(cmd *cobra.Command, args []string) {
 	fmt.Println()
 }
 
+// installCommand é a instalação manual pelo script do repositório de releases
+func installCommand() string {
+	return fmt.Sprintf("curl -fsSL https://raw.githubusercontent.com/%s/main/install.sh | bash", cliConfig.ReleaseRepo)
+}
+
 func getLatestVersion() (*GitHubRelease, error) {
 	// Usar gh CLI para acessar repo privado
 	cmd := exec.Command("gh", "api",
-		fmt.Sprintf("/repos/%s/%s/releases/latest", repoOwner, repoName),
+		fmt.Sprintf("/repos/%s/releases/latest", cliConfig.ReleaseRepo),
 	)
 
 	output, err := cmd.Output()
@@ -152,7 +157,7 @@ func getLatestVersion() (*GitHubRelease, error) {
 }
 
 func getLatestVersionHTTP() (*GitHubRelease, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", repoOwner, repoName)
+	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", cliConfig.ReleaseRepo)
 
 	client := &http.Client{Timeout: 10 * time.Second}
 	resp, err := client.Get(url)
@@ -192,7 +197,7 @@ func runInstallScript() error {
 	// Baixar release via gh CLI (funciona com repo privado)
 	pattern := fmt.Sprintf("algarys_%s_%s.tar.gz", goos, goarch)
 	dlCmd := exec.Command("gh", "release", "download", "--repo",
-		fmt.Sprintf("%s/%s", repoOwner, repoName),
+		cliConfig.ReleaseRepo,
 		"--pattern", pattern, "--dir", tmpDir)
 	dlCmd.Stdout = nil
 	dlCmd.Stderr = nil
@@ -0,0 +1,602 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/algarys/algarys_cli/cmd/ui"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// Caminho do template embutido dentro do repositório do CLI
+const cliTemplatePath = "cmd/" + defaultTemplateRoot
+
+type changeStatus int
+
+const (
+	changeAdded changeStatus = iota
+	changeUpdated
+	changeRemoved
+	changeConflict // gravado com marcadores <<<<<<< / >>>>>>>
+	changeKept     // mudança do template não aplicada: o arquivo do projeto fica como está
+)
+
+// fileChange é o resultado do merge de um arquivo do template
+type fileChange struct {
+	Path    string
+	Status  changeStatus
+	Current []byte // conteúdo atual no projeto (nil se não existe)
+	Result  []byte // conteúdo final (nil para remoção ou arquivo mantido)
+	Mode    os.FileMode
+	Note    string
+}
+
+var (
+	upgradeDryRun bool
+	upgradeYes    bool
+	upgradeRef    string
+)
+
+var upgradeProjectCmd = &cobra.Command{
+	Use:   "upgrade-project",
+	Short: "Aplica atualizações do template ao projeto atual",
+	Long: `Atualiza um projeto gerado pelo 'algarys init' com as mudanças do template.
+
+O template é renderizado na revisão registrada no .algarys.toml e na
+revisão nova, com as mesmas respostas. As mudanças entre as duas são
+aplicadas no projeto com merge de três vias (git merge-file): alterações
+locais são preservadas e conflitos ficam marcados com <<<<<<< / >>>>>>>.
+Arquivos binários alterados dos dois lados e arquivos apagados no projeto
+são mantidos como estão.
+
+Templates git usam o cache em ~/.algarys/templates e funcionam sem rede
+se a revisão já estiver no cache.`,
+	Run: runUpgradeProject,
+}
+
+func init() {
+	upgradeProjectCmd.Flags().BoolVar(&upgradeDryRun, "dry-run", false, "Só mostrar o diff, sem alterar arquivos")
+	upgradeProjectCmd.Flags().BoolVarP(&upgradeYes, "yes", "y", false, "Aplicar sem pedir confirmação")
+	upgradeProjectCmd.Flags().StringVar(&upgradeRef, "ref", "", "Ref do template para atualizar (padrão: a mesma ref/última versão)")
+	rootCmd.AddCommand(upgradeProjectCmd)
+}
+
+func runUpgradeProject(cmd *cobra.Command, args []string) {
+	fmt.Println()
+
+	root, manifest, err := loadCurrentProject()
+	if err != nil {
+		fmt.Println(ui.RenderError(err.Error()))
+		fmt.Println()
+		os.Exit(1)
+	}
+
+	if dirty, _ := runGit(root, "status", "--porcelain"); dirty != "" {
+		fmt.Println(ui.RenderWarning("Há alterações não commitadas no projeto; commit antes facilita revisar o upgrade"))
+		fmt.Println()
+	}
+
+	spinner := ui.NewSpinner(ui.IconPackage + "  Renderizando versões do template")
+	spinner.Start()
+
+	oldFiles, newTpl, newFiles, err := renderTemplateRevisions(*manifest, upgradeRef)
+	if err != nil {
+		spinner.Error("Erro ao carregar o template")
+		fmt.Println(ui.RenderError(err.Error()))
+		fmt.Println()
+		os.Exit(1)
+	}
+	spinner.Success(fmt.Sprintf("Template %s → %s", describeRevision(manifest.Template.Ref, manifest.Template.Commit), describeRevision(newTpl.Ref, newTpl.Commit)))
+
+	changes, err := planUpgrade(root, oldFiles, newFiles)
+	if err != nil {
+		fmt.Println(ui.RenderError(err.Error()))
+		fmt.Println()
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	if len(changes) == 0 {
+		fmt.Println(ui.RenderSuccess("Projeto já está atualizado com o template"))
+		fmt.Println()
+		return
+	}
+
+	printUpgradePreview(changes)
+
+	if upgradeDryRun {
+		fmt.Println(ui.RenderInfo("Dry-run: nenhum arquivo foi alterado"))
+		fmt.Println()
+		return
+	}
+
+	if !upgradeYes {
+		fmt.Print(lipgloss.NewStyle().Foreground(ui.Primary).Render("  Aplicar as mudanças? [S/n] "))
+
+		var response string
+		fmt.Scanln(&response)
+
+		if response != "" && strings.ToLower(response) != "s" && strings.ToLower(response) != "sim" {
+			fmt.Println()
+			fmt.Println(ui.RenderInfo("Upgrade cancelado"))
+			fmt.Println()
+			return
+		}
+		fmt.Println()
+	}
+
+	if err := applyUpgrade(root, changes); err != nil {
+		fmt.Println(ui.RenderError(fmt.Sprintf("Erro ao aplicar: %v", err)))
+		fmt.Println()
+		os.Exit(1)
+	}
+
+	manifest.CLIVersion = Version
+	manifest.Template.Ref = newTpl.Ref
+	manifest.Template.Commit = newTpl.Commit
+	manifest.Template.Repo = newTpl.Repo
+	manifest.Template.Subdir = newTpl.Subdir
+	if err := writeProjectManifest(root, *manifest); err != nil {
+		fmt.Println(ui.RenderError(fmt.Sprintf("Erro ao atualizar %s: %v", projectManifestFile, err)))
+		os.Exit(1)
+	}
+
+	conflicts, kept := 0, 0
+	for _, c := range changes {
+		switch c.Status {
+		case changeConflict:
+			conflicts++
+		case changeKept:
+			kept++
+		}
+	}
+
+	if conflicts > 0 {
+		fmt.Println(ui.RenderWarning(fmt.Sprintf("%d arquivo(s) com conflito: resolva os marcadores <<<<<<< antes de commitar", conflicts)))
+	}
+	if kept > 0 {
+		fmt.Println(ui.RenderWarning(fmt.Sprintf("%d arquivo(s) mantidos como estão no projeto: aplique à mão as mudanças do template se quiser", kept)))
+	}
+	if conflicts == 0 && kept == 0 {
+		fmt.Println(ui.RenderSuccess("Projeto atualizado com o template"))
+	}
+	fmt.Println()
+}
+
+// renderTemplateRevisions renderiza o template na revisão do manifesto e na
+// revisão nova, com as respostas salvas no projeto
+func renderTemplateRevisions(m ProjectManifest, newRef string) (oldFiles []RenderedFile, newTpl *Template, newFiles []RenderedFile, err error) {
+	ctx := newTemplateContext(m.Project, m.Module)
+
+	oldTpl, cleanup, err := templateAtRevision(m.Template)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("versão antiga do template: %v", err)
+	}
+	// A versão antiga precisa ser renderizada antes de buscar a nova: os dois
+	// podem compartilhar o mesmo diretório de cache
+	oldFiles, err = renderTemplate(oldTpl.FS, ctx)
+	cleanup()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	newTpl, err = latestTemplate(m.Template, newRef)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("versão nova do template: %v", err)
+	}
+	newFiles, err = renderTemplate(newTpl.FS, ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return oldFiles, newTpl, newFiles, nil
+}
+
+// templateAtRevision carrega o template exatamente na revisão registrada
+func templateAtRevision(rev ManifestTemplate) (*Template, func(), error) {
+	noop := func() {}
+
+	switch {
+	case rev.ID == defaultTemplateID:
+		// O template embutido de outra versão vem do repositório do CLI
+		if rev.Ref == "v"+Version {
+			tpl, err := resolveTemplate("")
+			return tpl, noop, err
+		}
+		ref := rev.Ref
+		if rev.Commit != "" {
+			ref = rev.Commit
+		}
+		tpl, err := cliRepoTemplate(ref)
+		return tpl, noop, err
+
+	case isLocalTemplate(rev.ID):
+		// Template local: a versão antiga sai de um clone temporário no commit salvo
+		if rev.Commit == "" {
+			return nil, noop, fmt.Errorf("template local sem commit registrado; não há como reconstruir a versão antiga")
+		}
+		tmpDir, err := os.MkdirTemp("", "algarys-template-*")
+		if err != nil {
+			return nil, noop, err
+		}
+		cleanup := func() { os.RemoveAll(tmpDir) }
+		repo := rev.Repo
+		if repo == "" {
+			repo = rev.ID
+		}
+		if _, err := runGit("", "clone", "-q", "--no-checkout", repo, tmpDir); err != nil {
+			cleanup()
+			return nil, noop, err
+		}
+		if _, err := runGit(tmpDir, "checkout", "-q", "--detach", rev.Commit); err != nil {
+			cleanup()
+			return nil, noop, err
+		}
+		tpl := &Template{ID: rev.ID, Commit: rev.Commit, Repo: rev.Repo, Subdir: rev.Subdir, Dir: filepath.Join(tmpDir, filepath.FromSlash(rev.Subdir))}
+		if err := tpl.load(); err != nil {
+			cleanup()
+			return nil, noop, err
+		}
+		return tpl, cleanup, nil
+
+	default:
+		ref := rev.Commit
+		if ref == "" {
+			ref = rev.Ref
+		}
+		dir, commit, err := fetchGitTemplate(rev.ID, ref)
+		if err != nil {
+			return nil, noop, err
+		}
+		tpl := &Template{ID: rev.ID, Ref: rev.Ref, Commit: commit, Dir: dir}
+		return tpl, noop, tpl.load()
+	}
+}
+
+// latestTemplate carrega a revisão nova: o embutido do CLI atual, o
+// conteúdo atual de um template local ou a ref pedida de um template git
+func latestTemplate(rev ManifestTemplate, ref string) (*Template, error) {
+	switch {
+	case rev.ID == defaultTemplateID:
+		if ref != "" {
+			return cliRepoTemplate(ref)
+		}
+		return resolveTemplate("")
+
+	case isLocalTemplate(rev.ID):
+		// O manifesto vai guardar o commit do HEAD: alterações fora dele
+		// não teriam como ser reconstruídas no próximo upgrade
+		if dirty, err := runGit(rev.ID, "status", "--porcelain", "--", "."); err == nil && dirty != "" {
+			return nil, fmt.Errorf("o template local %s tem alterações não commitadas; faça commit antes do upgrade", rev.ID)
+		}
+		return resolveTemplate(rev.ID)
+
+	default:
+		if ref == "" {
+			ref = rev.Ref
+		}
+		dir, commit, err := fetchGitTemplate(rev.ID, ref)
+		if err != nil {
+			return nil, err
+		}
+		tpl := &Template{ID: rev.ID, Ref: ref, Commit: commit, Dir: dir}
+		return tpl, tpl.load()
+	}
+}
+
+// cliRepoTemplate busca o template embutido de uma versão do CLI no
+// repositório (privado na org: o git autentica pelo gh)
+func cliRepoTemplate(ref string) (*Template, error) {
+	url := fmt.Sprintf("https://github.com/%s.git", cliConfig.ReleaseRepo)
+	dir, commit, err := fetchGitTemplate(url, ref, githubCredentialArgs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	treeDir := filepath.Join(dir, filepath.FromSlash(cliTemplatePath))
+	if _, err := os.Stat(treeDir); err != nil {
+		return nil, fmt.Errorf("template não encontrado na versão %s do CLI", ref)
+	}
+	return &Template{ID: defaultTemplateID, Ref: ref, Commit: commit, Dir: dir, FS: os.DirFS(treeDir)}, nil
+}
+
+func describeRevision(ref, commit string) string {
+	if len(commit) > 8 {
+		commit = commit[:8]
+	}
+	switch {
+	case ref != "" && commit != "":
+		return fmt.Sprintf("%s (%s)", ref, commit)
+	case ref != "":
+		return ref
+	case commit != "":
+		return commit
+	}
+	return "?"
+}
+
+// planUpgrade calcula as mudanças: base = template antigo, theirs = template
+// novo, ours = arquivo atual do projeto
+func planUpgrade(root string, oldFiles, newFiles []RenderedFile) ([]fileChange, error) {
+	base := renderedByPath(oldFiles)
+	theirs := renderedByPath(newFiles)
+	modes := make(map[string]os.FileMode, len(newFiles))
+	for _, f := range newFiles {
+		modes[f.Path] = f.Mode
+	}
+
+	paths := make([]string, 0, len(base)+len(theirs))
+	for p := range base {
+		paths = append(paths, p)
+	}
+	for p := range theirs {
+		if _, ok := base[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	var changes []fileChange
+	for _, p := range paths {
+		oldContent, inBase := base[p]
+		newContent, inNew := theirs[p]
+
+		// Template não mudou este arquivo
+		if inBase && inNew && bytes.Equal(oldContent, newContent) {
+			continue
+		}
+
+		dest := filepath.Join(root, filepath.FromSlash(p))
+		current, err := os.ReadFile(dest)
+		exists := err == nil
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		mode := modes[p]
+		if exists {
+			if info, err := os.Stat(dest); err == nil {
+				mode = info.Mode().Perm()
+			}
+		}
+
+		switch {
+		case !inNew:
+			// Removido do template
+			if !exists {
+				continue
+			}
+			if bytes.Equal(current, oldContent) {
+				changes = append(changes, fileChange{Path: p, Status: changeRemoved, Current: current})
+			} else {
+				changes = append(changes, fileChange{Path: p, Status: changeKept,
+					Note: "removido do template, mas alterado no projeto"})
+			}
+
+		case !exists:
+			if inBase {
+				// Apagado no projeto: respeitar a decisão local
+				changes = append(changes, fileChange{Path: p, Status: changeKept,
+					Note: "alterado no template, mas removido no projeto"})
+				continue
+			}
+			changes = append(changes, fileChange{Path: p, Status: changeAdded, Result: newContent, Mode: mode})
+
+		case bytes.Equal(current, newContent):
+			continue
+
+		case inBase && bytes.Equal(current, oldContent):
+			changes = append(changes, fileChange{Path: p, Status: changeUpdated, Current: current, Result: newContent, Mode: mode})
+
+		case isBinary(current) || isBinary(oldContent) || isBinary(newContent):
+			// merge-file só entende texto: com mudanças dos dois lados, fica a versão local
+			changes = append(changes, fileChange{Path: p, Status: changeKept,
+				Note: "binário alterado no template e no projeto"})
+
+		default:
+			merged, conflict, err := mergeThreeWay(current, oldContent, newContent)
+			if err != nil {
+				return nil, fmt.Errorf("erro no merge de %s: %v", p, err)
+			}
+			status := changeUpdated
+			if conflict {
+				status = changeConflict
+			}
+			if bytes.Equal(merged, current) {
+				continue
+			}
+			changes = append(changes, fileChange{Path: p, Status: status, Current: current, Result: merged, Mode: mode})
+		}
+	}
+
+	return changes, nil
+}
+
+func renderedByPath(files []RenderedFile) map[string][]byte {
+	m := make(map[string][]byte, len(files))
+	for _, f := range files {
+		m[f.Path] = f.Content
+	}
+	return m
+}
+
+// isBinary usa a mesma heurística do git: um byte nulo no começo do arquivo
+func isBinary(content []byte) bool {
+	return bytes.IndexByte(content[:min(len(content), 8000)], 0) >= 0
+}
+
+// mergeThreeWay usa git merge-file para aplicar base→theirs em ours
+func mergeThreeWay(ours, base, theirs []byte) ([]byte, bool, error) {
+	tmpDir, err := os.MkdirTemp("", "algarys-merge-*")
+	if err != nil {
+		return nil, false, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	paths := []string{
+		filepath.Join(tmpDir, "ours"),
+		filepath.Join(tmpDir, "base"),
+		filepath.Join(tmpDir, "theirs"),
+	}
+	for i, content := range [][]byte{ours, base, theirs} {
+		if err := os.WriteFile(paths[i], content, 0644); err != nil {
+			return nil, false, err
+		}
+	}
+
+	cmd := exec.Command("git", "merge-file", "-p",
+		"-L", "projeto", "-L", "template antigo", "-L", "template novo",
+		paths[0], paths[1], paths[2])
+	output, err := cmd.Output()
+	if err != nil {
+		// Exit code de 1 a 127 = número de conflitos; acima disso, o merge falhou
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			if code := exitErr.ExitCode(); code > 0 && code < 128 {
+				return output, true, nil
+			}
+			if len(exitErr.Stderr) > 0 {
+				return nil, false, fmt.Errorf("git merge-file: %s", strings.TrimSpace(string(exitErr.Stderr)))
+			}
+		}
+		return nil, false, fmt.Errorf("git merge-file: %v", err)
+	}
+	return output, false, nil
+}
+
+func printUpgradePreview(changes []fileChange) {
+	titleStyle := lipgloss.NewStyle().Foreground(ui.Primary).Bold(true).PaddingLeft(2)
+	fmt.Println(titleStyle.Render("Mudanças do template:"))
+	fmt.Println()
+
+	for _, c := range changes {
+		var label string
+		switch c.Status {
+		case changeAdded:
+			label = ui.SuccessStyle.Render("novo      ")
+		case changeUpdated:
+			label = ui.AccentStyle.Render("atualizado")
+		case changeRemoved:
+			label = ui.ErrorStyle.Render("removido  ")
+		case changeConflict:
+			label = ui.WarningStyle.Render("conflito  ")
+		case changeKept:
+			label = ui.MutedStyle.Render("mantido   ")
+		}
+		line := fmt.Sprintf("    %s  %s", label, c.Path)
+		if c.Note != "" {
+			line += ui.MutedStyle.Render("  (" + c.Note + ")")
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+
+	for _, c := range changes {
+		if c.Result == nil && c.Current == nil {
+			continue
+		}
+		diff := unifiedDiff(c.Path, c.Current, c.Result)
+		if diff == "" {
+			continue
+		}
+		fmt.Println(renderDiff(diff))
+	}
+}
+
+// unifiedDiff gera o diff entre before e after com git diff --no-index
+func unifiedDiff(path string, before, after []byte) string {
+	tmpDir, err := os.MkdirTemp("", "algarys-diff-*")
+	if err != nil {
+		return ""
+	}
+	defer os.RemoveAll(tmpDir)
+
+	a := filepath.Join(tmpDir, "a")
+	b := filepath.Join(tmpDir, "b")
+	os.WriteFile(a, before, 0644)
+	os.WriteFile(b, after, 0644)
+
+	cmd := exec.Command("git", "diff", "--no-index", "--no-color", "--no-prefix", a, b)
+	output, _ := cmd.Output() // exit 1 quando há diferenças
+
+	// Trocar os caminhos temporários pelo caminho do arquivo no projeto
+	lines := strings.Split(string(output), "\n")
+	var result []string
+	for _, l := range lines {
+		switch {
+		case strings.HasPrefix(l, "diff --git"), strings.HasPrefix(l, "index "),
+			strings.HasPrefix(l, "new file"), strings.HasPrefix(l, "deleted file"):
+			continue
+		case strings.HasPrefix(l, "--- "):
+			result = append(result, "--- "+path)
+		case strings.HasPrefix(l, "+++ "):
+			result = append(result, "+++ "+path)
+		default:
+			result = append(result, l)
+		}
+	}
+	return strings.TrimRight(strings.Join(result, "\n"), "\n")
+}
+
+func renderDiff(diff string) string {
+	var out []string
+	for _, l := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(l, "+++"), strings.HasPrefix(l, "---"):
+			out = append(out, "  "+lipgloss.NewStyle().Bold(true).Render(l))
+		case strings.HasPrefix(l, "@@"):
+			out = append(out, "  "+ui.AccentStyle.Render(l))
+		case strings.HasPrefix(l, "+"):
+			out = append(out, "  "+ui.SuccessStyle.Render(l))
+		case strings.HasPrefix(l, "-"):
+			out = append(out, "  "+ui.ErrorStyle.Render(l))
+		default:
+			out = append(out, "  "+l)
+		}
+	}
+	return strings.Join(out, "\n") + "\n"
+}
+
+// applyUpgrade grava o resultado do merge no projeto
+func applyUpgrade(root string, changes []fileChange) error {
+	for _, c := range changes {
+		dest := filepath.Join(root, filepath.FromSlash(c.Path))
+
+		switch {
+		case c.Status == changeRemoved:
+			if err := os.Remove(dest); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		case c.Status == changeKept || c.Result == nil:
+			fmt.Println(ui.RenderWarning(c.Path + " (mantido)"))
+			continue
+		default:
+			mode := c.Mode
+			if mode == 0 {
+				mode = 0644
+			}
+			if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(dest, c.Result, mode); err != nil {
+				return err
+			}
+			// WriteFile só aplica o modo ao criar: arquivos existentes mantêm o seu
+			if err := os.Chmod(dest, mode); err != nil {
+				return err
+			}
+		}
+
+		if c.Status == changeConflict {
+			fmt.Println(ui.RenderWarning(c.Path + " (conflito)"))
+		} else {
+			fmt.Println(ui.RenderSuccess(c.Path))
+		}
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPlanUpgrade(t *testing.T) {
+	tests := []struct {
+		name       string
+		base       string // template antigo ("" = não existia)
+		theirs     string // template novo ("" = removido)
+		ours       string // arquivo no projeto ("" = apagado)
+		wantStatus changeStatus
+		wantResult string
+		conflict   bool // resultado com marcadores
+	}{
+		{
+			name:       "merge limpo",
+			base:       "a\nb\nc\n",
+			theirs:     "a\nb\nc\nd\n",
+			ours:       "x\nb\nc\n",
+			wantStatus: changeUpdated,
+			wantResult: "x\nb\nc\nd\n",
+		},
+		{
+			name:       "conflito",
+			base:       "a\n",
+			theirs:     "template\n",
+			ours:       "projeto\n",
+			wantStatus: changeConflict,
+			conflict:   true,
+		},
+		{
+			name:       "apagado no projeto",
+			base:       "a\n",
+			theirs:     "b\n",
+			wantStatus: changeKept,
+		},
+		{
+			name:       "removido do template e alterado no projeto",
+			base:       "a\n",
+			ours:       "projeto\n",
+			wantStatus: changeKept,
+		},
+		{
+			name:       "binário alterado dos dois lados",
+			base:       "\x00base",
+			theirs:     "\x00template",
+			ours:       "\x00projeto",
+			wantStatus: changeKept,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			if tt.ours != "" {
+				if err := os.WriteFile(filepath.Join(root, "file"), []byte(tt.ours), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var oldFiles, newFiles []RenderedFile
+			if tt.base != "" {
+				oldFiles = []RenderedFile{{Path: "file", Content: []byte(tt.base), Mode: 0644}}
+			}
+			if tt.theirs != "" {
+				newFiles = []RenderedFile{{Path: "file", Content: []byte(tt.theirs), Mode: 0644}}
+			}
+
+			changes, err := planUpgrade(root, oldFiles, newFiles)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(changes) != 1 {
+				t.Fatalf("%d mudanças, esperado 1: %+v", len(changes), changes)
+			}
+			c := changes[0]
+			if c.Status != tt.wantStatus {
+				t.Errorf("status = %d, esperado %d (%s)", c.Status, tt.wantStatus, c.Note)
+			}
+			if tt.wantResult != "" && string(c.Result) != tt.wantResult {
+				t.Errorf("resultado = %q, esperado %q", c.Result, tt.wantResult)
+			}
+			if hasMarkers := bytes.Contains(c.Result, []byte("<<<<<<< projeto")); hasMarkers != tt.conflict {
+				t.Errorf("marcadores de conflito = %v, esperado %v:\n%s", hasMarkers, tt.conflict, c.Result)
+			}
+			if tt.wantStatus == changeKept && c.Result != nil {
+				t.Errorf("arquivo mantido não deveria ter resultado: %q", c.Result)
+			}
+		})
+	}
+}
+
+func TestApplyUpgradeKeepsFileMode(t *testing.T) {
+	root := t.TempDir()
+	script := filepath.Join(root, "run.sh")
+	if err := os.WriteFile(script, []byte("echo a\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := planUpgrade(root,
+		[]RenderedFile{{Path: "run.sh", Content: []byte("echo a\n"), Mode: 0644}},
+		[]RenderedFile{{Path: "run.sh", Content: []byte("echo b\n"), Mode: 0644}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := applyUpgrade(root, changes); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("modo = %v, esperado 0755", info.Mode().Perm())
+	}
+}
+
+func TestMergeThreeWayFailure(t *testing.T) {
+	// Sem git no PATH o merge falha: o erro não pode virar conteúdo mesclado
+	t.Setenv("PATH", t.TempDir())
+	merged, conflict, err := mergeThreeWay([]byte("a\n"), []byte("b\n"), []byte("c\n"))
+	if err == nil || conflict || merged != nil {
+		t.Errorf("mergeThreeWay() = %q, %v, %v; esperado erro", merged, conflict, err)
+	}
+}
+
+func TestLocalTemplateInRepoSubfolder(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git não encontrado")
+	}
+	repo := t.TempDir()
+	tplDir := filepath.Join(repo, "templates", "agent")
+	writeVersion := func(content string) {
+		if err := os.MkdirAll(tplDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(tplDir, "VERSION"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gitTest(t, repo, "init", "-q", "-b", "main")
+	writeVersion("v1")
+	gitTest(t, repo, "add", ".")
+	gitTest(t, repo, "commit", "-q", "-m", "v1")
+
+	tpl, err := resolveTemplate(tplDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tpl.Subdir != "templates/agent" {
+		t.Errorf("Subdir = %q, esperado templates/agent", tpl.Subdir)
+	}
+	manifest := newProjectManifest(ProjectConfig{Name: "demo"}, "demo", tpl)
+
+	writeVersion("v2")
+	gitTest(t, repo, "commit", "-q", "-am", "v2")
+
+	oldFiles, _, newFiles, err := renderTemplateRevisions(manifest, "")
+	if err != nil {
+		t.Fatalf("upgrade: %v", err)
+	}
+	if got := string(renderedByPath(oldFiles)["VERSION"]); got != "v1" {
+		t.Errorf("versão antiga = %q, esperado v1 (só a subpasta do template)", got)
+	}
+	if got := string(renderedByPath(newFiles)["VERSION"]); got != "v2" {
+		t.Errorf("versão nova = %q, esperado v2", got)
+	}
+
+	// Alterações fora do commit não podem entrar no lado novo
+	writeVersion("v3")
+	if _, _, _, err := renderTemplateRevisions(manifest, ""); err == nil || !strings.Contains(err.Error(), "não commitadas") {
+		t.Errorf("template sujo: err = %v, esperado recusa", err)
+	}
+}
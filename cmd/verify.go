@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/algarys/algarys_cli/cmd/ui"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// projectCheck é uma ferramenta rodada na verificação final do init
+type projectCheck struct {
+	name    string
+	args    []string
+	summary *regexp.Regexp // linha da saída que resume uma falha
+}
+
+// verifySyncCommand instala os extras (ruff, mypy e pytest estão no dev)
+func verifySyncCommand() []string {
+	return []string{"uv", "sync", "--all-extras"}
+}
+
+// projectChecks são os mesmos passos do CI gerado pelo template
+func projectChecks(moduleName string) []projectCheck {
+	return []projectCheck{
+		{
+			name:    "ruff",
+			args:    []string{"uv", "run", "--all-extras", "ruff", "check", "."},
+			summary: regexp.MustCompile(`^Found \d+ errors?`),
+		},
+		{
+			name:    "mypy",
+			args:    []string{"uv", "run", "--all-extras", "mypy", moduleName + "/"},
+			summary: regexp.MustCompile(`^Found \d+ errors? in`),
+		},
+		{
+			name:    "pytest",
+			args:    []string{"uv", "run", "--all-extras", "pytest", "-q"},
+			summary: regexp.MustCompile(`\d+ (failed|errors?)\b`),
+		},
+	}
+}
+
+// pytest sai com 5 quando não encontra nenhum teste
+const pytestNoTestsExitCode = 5
+
+// verifyProject roda ruff, mypy e pytest no projeto recém-criado, uma etapa
+// por ferramenta. Falhas viram aviso e, no fim, mostram como reproduzir.
+func verifyProject(projectDir, moduleName string) {
+	checks := projectChecks(moduleName)
+
+	synced := false
+	steps := []initStep{
+		{icon: ui.IconPackage, message: "Instalando dependências de desenvolvimento", optional: true, action: func() error {
+			if _, err := exec.LookPath("uv"); err != nil {
+				return errStepSkipped
+			}
+			if err := runCommand(projectDir, nil, verifySyncCommand()); err != nil {
+				return err
+			}
+			synced = true
+			return nil
+		}},
+	}
+
+	var failed []projectCheck
+	for _, check := range checks {
+		check := check
+		steps = append(steps, initStep{
+			icon:     ui.IconMagic,
+			message:  "Verificando com " + check.name,
+			optional: true,
+			action: func() error {
+				if !synced {
+					return errStepSkipped
+				}
+				err := runCheck(projectDir, check)
+				if err != nil && !errors.Is(err, errStepSkipped) {
+					failed = append(failed, check)
+				}
+				return err
+			},
+		})
+	}
+
+	runInitSteps(steps)
+
+	if len(failed) == 0 {
+		return
+	}
+	fmt.Println(lipgloss.NewStyle().Foreground(ui.Muted).PaddingLeft(2).Render(
+		"Para ver os detalhes, dentro do projeto:",
+	))
+	for _, check := range failed {
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.Primary).PaddingLeft(4).Render(shellJoin(check.args)))
+	}
+}
+
+// runCheck executa a ferramenta; o erro resume a saída numa linha
+func runCheck(projectDir string, check projectCheck) error {
+	cmd := exec.Command(check.args[0], check.args[1:]...)
+	cmd.Dir = projectDir
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := cmd.Run()
+	if err == nil {
+		return nil
+	}
+
+	var exitErr *exec.ExitError
+	if check.name == "pytest" && errors.As(err, &exitErr) && exitErr.ExitCode() == pytestNoTestsExitCode {
+		return fmt.Errorf("%w: nenhum teste encontrado", errStepSkipped)
+	}
+
+	if line := summaryLine(output.String(), check.summary); line != "" {
+		return fmt.Errorf("%s", line)
+	}
+	return err
+}
+
+// summaryLine devolve a última linha que casa com re ou, sem nenhuma (ou
+// com re nil), a última linha não vazia da saída
+func summaryLine(output string, re *regexp.Regexp) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	for i := len(lines) - 1; re != nil && i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); re.MatchString(line) {
+			return line
+		}
+	}
+	return strings.TrimSpace(lines[len(lines)-1])
+}
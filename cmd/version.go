@@ -17,6 +17,8 @@ var (
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Mostra a versão do CLI",
+	// Roda mesmo com a configuração inválida (ver requireCLIConfig)
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Println()
 